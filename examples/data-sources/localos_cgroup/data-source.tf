@@ -0,0 +1,15 @@
+data "localos_cgroup" "this" {}
+
+locals {
+  # Run one build per CPU the CI container may use, and no more than fit in its memory at 2 GiB each
+  parallelism = max(1, min(
+    floor(data.localos_cgroup.this.effective_cpus),
+    floor(data.localos_cgroup.this.effective_memory_bytes / 2147483648),
+  ))
+}
+
+resource "terraform_data" "build" {
+  provisioner "local-exec" {
+    command = "make -j${local.parallelism} images"
+  }
+}
@@ -0,0 +1,18 @@
+data "localos_ci" "this" {}
+
+locals {
+  # Tag resources with where they were deployed from, leaving out what the CI system does not provide
+  origin_tags = { for k, v in {
+    "deployed-by"  = data.localos_ci.this.is_ci ? coalesce(data.localos_ci.this.provider_name, "ci") : "local"
+    "repository"   = data.localos_ci.this.repository
+    "branch"       = data.localos_ci.this.branch
+    "commit"       = data.localos_ci.this.commit_sha
+    "pull-request" = data.localos_ci.this.pull_request_number
+    "job-url"      = data.localos_ci.this.job_url
+    "actor"        = data.localos_ci.this.actor
+  } : k => tostring(v) if v != null }
+}
+
+output "origin_tags" {
+  value = local.origin_tags
+}
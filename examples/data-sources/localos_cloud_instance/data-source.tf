@@ -0,0 +1,17 @@
+data "localos_cloud_instance" "this" {}
+
+# Allow SSH only from the bastion the deployment runs on, or from the
+# public IP of the workstation when run locally
+data "localos_public_ip" "this" {}
+
+locals {
+  admin_cidr = data.localos_cloud_instance.this.is_cloud ? "${coalesce(data.localos_cloud_instance.this.public_ip, data.localos_cloud_instance.this.private_ip)}/32" : data.localos_public_ip.this.cidr
+}
+
+output "admin_cidr" {
+  value = local.admin_cidr
+}
+
+output "deployed_from" {
+  value = data.localos_cloud_instance.this.is_cloud ? "${data.localos_cloud_instance.this.provider_name} ${data.localos_cloud_instance.this.region} ${data.localos_cloud_instance.this.instance_id}" : "local"
+}
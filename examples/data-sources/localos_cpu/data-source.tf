@@ -0,0 +1,13 @@
+data "localos_cpu" "this" {}
+
+locals {
+  # Leave a couple of CPUs for the host when sizing a local VM
+  vm_cpus = max(1, data.localos_cpu.this.available_cpus - 2)
+
+  # Choose an image built for the CPU features available
+  image_variant = contains(data.localos_cpu.this.flags, "avx2") ? "x86-64-v3" : "x86-64"
+}
+
+output "cpu" {
+  value = "${data.localos_cpu.this.model_name}: ${data.localos_cpu.this.physical_cores} cores, ${data.localos_cpu.this.logical_cpus} threads"
+}
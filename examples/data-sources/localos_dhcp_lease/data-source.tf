@@ -0,0 +1,15 @@
+data "localos_private_ip" "ip" {}
+
+# Lease for the primary interface
+data "localos_dhcp_lease" "lease" {}
+
+output "lan_domain" {
+  value = data.localos_dhcp_lease.lease.domain
+}
+
+check "address_stable" {
+  assert {
+    condition     = !data.localos_dhcp_lease.lease.found || timecmp(data.localos_dhcp_lease.lease.expiry, timeadd(plantimestamp(), "24h")) > 0
+    error_message = "The DHCP lease for ${data.localos_private_ip.ip.primary.ip} expires within a day, after which the address may change"
+  }
+}
@@ -0,0 +1,19 @@
+data "localos_dns_config" "dns" {}
+
+output "nameservers" {
+  value = data.localos_dns_config.dns.nameservers
+}
+
+output "search_domains" {
+  value = data.localos_dns_config.dns.search
+}
+
+# If systemd-resolved is in use, nameservers will be the local stub (127.0.0.53)
+# and the servers actually queried are in upstream_nameservers.
+output "effective_nameservers" {
+  value = (
+    data.localos_dns_config.dns.systemd_resolved_stub
+    ? data.localos_dns_config.dns.upstream_nameservers
+    : data.localos_dns_config.dns.nameservers
+  )
+}
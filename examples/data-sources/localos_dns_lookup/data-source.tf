@@ -0,0 +1,26 @@
+# Resolve a name that is only visible inside the corporate network
+data "localos_dns_lookup" "git" {
+  name = "git.corp.example.com"
+}
+
+output "git_addresses" {
+  value = data.localos_dns_lookup.git.values
+}
+
+# Query a specific name server
+data "localos_dns_lookup" "mail" {
+  name   = "corp.example.com"
+  type   = "MX"
+  server = "10.0.0.2"
+}
+
+output "mail_exchangers" {
+  # e.g. ["10 mx1.corp.example.com.", "20 mx2.corp.example.com."]
+  value = data.localos_dns_lookup.mail.values
+}
+
+# Reverse lookup
+data "localos_dns_lookup" "ptr" {
+  name = "10.0.0.10"
+  type = "PTR"
+}
@@ -0,0 +1,21 @@
+# Settings shared with the application, with optional local overrides
+data "localos_dotenv" "app" {
+  files = [
+    "${path.module}/.env",
+    "${path.module}/.env.local",
+  ]
+  ignore_missing = true
+  sensitive      = ["/(PASSWORD|SECRET|TOKEN)/"]
+}
+
+resource "aws_ssm_parameter" "db_host" {
+  name  = "/app/db_host"
+  type  = "String"
+  value = data.localos_dotenv.app.values["DB_HOST"]
+}
+
+resource "aws_ssm_parameter" "db_password" {
+  name  = "/app/db_password"
+  type  = "SecureString"
+  value = data.localos_dotenv.app.sensitive_values["DB_PASSWORD"]
+}
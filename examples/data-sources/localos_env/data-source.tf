@@ -0,0 +1,32 @@
+# Fail early if the CI job does not say which environment to deploy to
+data "localos_env" "deploy_env" {
+  name     = "DEPLOY_ENV"
+  required = true
+}
+
+data "localos_env" "replicas" {
+  name    = "REPLICAS"
+  type    = "number"
+  default = "2"
+}
+
+data "localos_env" "allowed_cidrs" {
+  name      = "ALLOWED_CIDRS"
+  type      = "list"
+  separator = " "
+  default   = "10.0.0.0/8"
+}
+
+data "localos_env" "api_token" {
+  name      = "API_TOKEN"
+  required  = true
+  sensitive = true
+}
+
+output "deployment" {
+  value = {
+    environment   = data.localos_env.deploy_env.value
+    replicas      = data.localos_env.replicas.number
+    allowed_cidrs = data.localos_env.allowed_cidrs.list
+  }
+}
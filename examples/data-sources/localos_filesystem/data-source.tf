@@ -0,0 +1,20 @@
+# Free space where VM images will be downloaded. The directory need not exist yet.
+data "localos_filesystem" "images" {
+  path = "${pathexpand("~")}/.local/share/images"
+}
+
+check "image_space" {
+  assert {
+    condition     = data.localos_filesystem.images.available_bytes >= 20 * 1073741824
+    error_message = "At least 20 GiB must be free on ${data.localos_filesystem.images.mount_point} to download images"
+  }
+}
+
+# All real disks, without pseudo and in-memory filesystems
+data "localos_filesystem" "disks" {
+  exclude_fs_types = ["proc", "sysfs", "devtmpfs", "devpts", "tmpfs", "cgroup", "cgroup2", "overlay", "squashfs", "mqueue"]
+}
+
+output "disks" {
+  value = { for m in data.localos_filesystem.disks.mounts : m.mount_point => "${m.fs_type}, ${floor(m.available_bytes / 1073741824)} GiB free" }
+}
@@ -0,0 +1,28 @@
+data "localos_hardware" "this" {
+  # Hashed, as hash_identifiers defaults to true. Requires running as root.
+  include_identifiers = true
+}
+
+locals {
+  is_laptop = contains(["laptop", "convertible"], coalesce(data.localos_hardware.this.chassis_class, "unknown"))
+
+  # Tag resources with the lab machine they were deployed from
+  hardware_tags = { for k, v in {
+    "lab-vendor"  = data.localos_hardware.this.system_vendor
+    "lab-model"   = data.localos_hardware.this.product_name
+    "lab-chassis" = data.localos_hardware.this.chassis_class
+    "lab-machine" = data.localos_hardware.this.uuid
+  } : k => v if v != null }
+}
+
+output "hardware_tags" {
+  value = local.hardware_tags
+}
+
+# Keep heavy test environments off laptops
+check "not_a_laptop" {
+  assert {
+    condition     = !local.is_laptop
+    error_message = "Run the full lab environment from a lab server, not a ${data.localos_hardware.this.chassis_type_name}."
+  }
+}
@@ -0,0 +1,10 @@
+data "localos_hostname" "this" {}
+
+# Tag resources with the machine they were deployed from
+resource "aws_s3_bucket" "example" {
+  bucket = "my-example-bucket"
+
+  tags = {
+    DeployedFrom = data.localos_hostname.this.fqdn
+  }
+}
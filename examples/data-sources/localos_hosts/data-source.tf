@@ -0,0 +1,16 @@
+data "localos_hosts" "hosts" {}
+
+locals {
+  ingress_ip = "192.168.49.2"
+}
+
+check "ingress_hosts_entry" {
+  assert {
+    condition     = contains(lookup(data.localos_hosts.hosts.addresses_by_name, "app.dev.local", []), local.ingress_ip)
+    error_message = "Add '${local.ingress_ip} app.dev.local' to ${data.localos_hosts.hosts.path}"
+  }
+}
+
+output "names_for_ingress" {
+  value = lookup(data.localos_hosts.hosts.names_by_address, local.ingress_ip, [])
+}
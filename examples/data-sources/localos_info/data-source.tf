@@ -13,6 +13,36 @@ output "os_is_windows" {
 }
 
 output "os_path_var" {
-  value = nonsensitive(data.localos_info.os_info.environment.PATH)
+  value = nonsensitive(data.localos_info.os_info.environment["PATH"])
 }
 
+
+# Only the variables that configure AWS, without the credentials
+data "localos_info" "aws" {
+  include = ["AWS_"]
+  exclude = ["/^AWS_(SECRET_ACCESS_KEY|SESSION_TOKEN)$/"]
+  public  = ["AWS_PROFILE", "AWS_REGION"]
+}
+
+output "aws_region" {
+  value = data.localos_info.aws.environment_public["AWS_REGION"]
+}
+
+output "search_path" {
+  value = data.localos_info.os_info.path
+}
+
+# Choose the package manager for a local-exec provisioner on Linux
+locals {
+  distro_family = concat([data.localos_info.os_info.distribution_id], data.localos_info.os_info.distribution_id_like)
+  package_manager = (
+    contains(local.distro_family, "debian") ? "apt-get" :
+    contains(local.distro_family, "fedora") || contains(local.distro_family, "rhel") ? "dnf" :
+    contains(local.distro_family, "alpine") ? "apk" :
+    null
+  )
+}
+
+output "distribution" {
+  value = "${data.localos_info.os_info.distribution_pretty_name} (kernel ${data.localos_info.os_info.kernel_release} on ${data.localos_info.os_info.machine})"
+}
@@ -0,0 +1,15 @@
+data "localos_listening_sockets" "vault_agent" {
+  protocol = "tcp"
+  port     = 8200
+}
+
+check "vault_agent_running" {
+  assert {
+    condition     = length(data.localos_listening_sockets.vault_agent.sockets) > 0
+    error_message = "Vault agent is not listening on port 8200"
+  }
+}
+
+output "vault_agent_processes" {
+  value = [for s in data.localos_listening_sockets.vault_agent.sockets : s.process]
+}
@@ -0,0 +1,13 @@
+# An ID for this workstation that only this configuration uses,
+# e.g. to give each developer their own sandbox environment
+data "localos_machine" "this" {
+  application_id = "sandbox-environments"
+}
+
+locals {
+  sandbox_name = "sandbox-${substr(data.localos_machine.this.machine_id, 0, 8)}"
+}
+
+output "sandbox_name" {
+  value = local.sandbox_name
+}
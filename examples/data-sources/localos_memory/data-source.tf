@@ -0,0 +1,13 @@
+data "localos_memory" "this" {}
+
+locals {
+  # Give a local VM half of the memory available, in MiB, capped at 16 GiB
+  vm_memory_mib = min(16384, floor(data.localos_memory.this.available_bytes / 2 / 1048576))
+
+  # Use huge pages for the VM if enough are free
+  vm_hugepages = data.localos_memory.this.hugepages_free * data.localos_memory.this.hugepage_size_bytes >= local.vm_memory_mib * 1048576
+}
+
+output "memory_gib" {
+  value = floor(data.localos_memory.this.total_bytes / 1073741824)
+}
@@ -0,0 +1,15 @@
+data "localos_neighbors" "lan" {
+  family    = "ipv4"
+  interface = "eth0"
+}
+
+locals {
+  # MAC addresses of devices that have been seen on the LAN, keyed by IP
+  lan_macs = {
+    for n in data.localos_neighbors.lan.neighbors : n.address => n.mac if n.mac != null
+  }
+}
+
+output "printer_mac" {
+  value = lookup(local.lan_macs, "192.168.1.50", null)
+}
@@ -0,0 +1,30 @@
+data "localos_proxy" "proxy" {
+  # Fall back to system wide settings if not set in the environment
+  files = ["/etc/environment"]
+
+  include_credentials = true
+}
+
+# Pass the operator's proxy settings to an instance
+resource "aws_instance" "builder" {
+  ami           = "ami-0123456789abcdef0"
+  instance_type = "t3.micro"
+
+  user_data = <<-EOT
+    #!/bin/bash
+    cat >> /etc/environment <<EOF
+    http_proxy=${data.localos_proxy.proxy.proxies_with_credentials["http_proxy"]}
+    https_proxy=${data.localos_proxy.proxy.proxies_with_credentials["https_proxy"]}
+    no_proxy=${data.localos_proxy.proxy.no_proxy}
+    EOF
+  EOT
+}
+
+# Check which proxy terraform itself will use to reach the registry
+data "localos_proxy" "registry" {
+  urls = ["https://registry.terraform.io"]
+}
+
+output "registry_proxy" {
+  value = data.localos_proxy.registry.evaluations[0].direct ? "direct" : data.localos_proxy.registry.evaluations[0].proxy
+}
@@ -0,0 +1,18 @@
+data "localos_runtime_environment" "this" {}
+
+data "localos_folders" "this" {}
+
+locals {
+  # In WSL, write keys to the Linux home directory, where ssh in WSL reads them, not the Windows profile
+  ssh_key_path = "${data.localos_folders.this.ssh}/id_ed25519_lab"
+
+  # Nested virtualisation is slow, so use a smaller local cluster in a VM or container
+  cluster_nodes = data.localos_runtime_environment.this.is_virtual_machine || data.localos_runtime_environment.this.is_container ? 1 : 3
+}
+
+check "not_in_kubernetes" {
+  assert {
+    condition     = !data.localos_runtime_environment.this.is_kubernetes
+    error_message = "This configuration creates a local cluster, and must not be run in a Kubernetes pod"
+  }
+}
@@ -0,0 +1,21 @@
+data "localos_tcp_check" "vpn" {
+  targets = [
+    "git.corp.example.com:443",
+    "vault.corp.example.com:8200",
+  ]
+  timeout = "2s"
+  tls     = true
+}
+
+check "vpn_connected" {
+  assert {
+    condition     = data.localos_tcp_check.vpn.all_reachable
+    error_message = "Corporate services are unreachable. Is the VPN connected?"
+  }
+}
+
+# Alternatively, fail the plan immediately if anything is unreachable
+data "localos_tcp_check" "registry" {
+  targets     = ["localhost:5000"]
+  require_all = true
+}
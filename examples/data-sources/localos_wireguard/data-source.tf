@@ -0,0 +1,19 @@
+data "localos_wireguard" "laptop" {
+  interface = "wg0"
+}
+
+locals {
+  wg0 = one(data.localos_wireguard.laptop.interfaces)
+}
+
+# Register this machine as a peer of a cloud WireGuard server
+resource "aws_ssm_parameter" "laptop_peer" {
+  name = "/wireguard/peers/${data.localos_hostname.this.short_name}"
+  type = "String"
+  value = jsonencode({
+    public_key  = local.wg0.public_key
+    allowed_ips = local.wg0.addresses
+  })
+}
+
+data "localos_hostname" "this" {}
@@ -0,0 +1,2 @@
+# The format is detected from the content of the file
+terraform import localos_env_file.dev_stack /home/me/.config/dev-stack/stack.env
@@ -0,0 +1,20 @@
+# Hand engineers a file of the endpoints and credentials of their dev stack
+resource "localos_env_file" "dev_stack" {
+  path   = pathexpand("~/.config/dev-stack/stack.env")
+  format = "posix"
+  header = "Dev stack ${terraform.workspace}. Managed by terraform, do not edit.\nLoad with: . ~/.config/dev-stack/stack.env"
+
+  variables = {
+    DATABASE_URL      = "postgres://${aws_db_instance.dev.endpoint}/app"
+    DATABASE_PASSWORD = random_password.db.result
+    QUEUE_URL         = aws_sqs_queue.dev.url
+  }
+}
+
+# The same for PowerShell users
+resource "localos_env_file" "dev_stack_ps" {
+  path   = pathexpand("~/.config/dev-stack/stack.ps1")
+  format = "powershell"
+
+  variables = localos_env_file.dev_stack.variables
+}
@@ -0,0 +1,5 @@
+# Block in the default hosts file
+terraform import localos_hosts_entry.dev_cluster dev-cluster
+
+# Block in another file
+terraform import localos_hosts_entry.dev_cluster dev-cluster@/etc/hosts.local
@@ -0,0 +1,17 @@
+variable "ingress_ip" {
+  type    = string
+  default = "192.168.49.2"
+}
+
+# Requires permission to write the hosts file,
+# e.g. run terraform with sudo or as Administrator.
+resource "localos_hosts_entry" "dev_cluster" {
+  name = "dev-cluster"
+
+  entries = [
+    {
+      address   = var.ingress_ip
+      hostnames = ["app.dev.local", "api.dev.local", "grafana.dev.local"]
+    },
+  ]
+}
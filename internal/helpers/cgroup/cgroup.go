@@ -0,0 +1,342 @@
+package cgroup
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/mounts"
+)
+
+// DefaultProcRoot is where procfs is mounted.
+const DefaultProcRoot = "/proc"
+
+// cgroup v1 reports no memory limit as the largest multiple of the page size, so anything above this is unlimited.
+const v1MemoryUnlimited = math.MaxInt64 / 2
+
+// Limits are the resource limits of the cgroup of a process. A value of 0 means no limit.
+type Limits struct {
+	// Version is 2 if the cpu and memory controllers are in the unified hierarchy, otherwise 1
+	Version int
+
+	// Path is the cgroup of the process, e.g. "/user.slice/user-1000.slice/session-2.scope".
+	// With cgroup v1, it is the cgroup in the memory hierarchy.
+	Path string
+
+	// CPUQuota is the CPU time in microseconds the cgroup may use in each CPUPeriod
+	CPUQuota  int64
+	CPUPeriod int64
+
+	// MemoryMax is the hard memory limit in bytes
+	MemoryMax int64
+
+	// MemoryHigh is the memory in bytes above which the cgroup is throttled. Only in cgroup v2.
+	MemoryHigh int64
+
+	PidsMax int64
+
+	// EffectiveCPUs is the lowest CPU limit of the cgroup and its ancestors, in CPUs, e.g. 1.5
+	EffectiveCPUs float64
+
+	// EffectiveMemory is the lowest memory limit of the cgroup and its ancestors, in bytes
+	EffectiveMemory int64
+}
+
+// entry is a line of /proc/<pid>/cgroup.
+type entry struct {
+	id          string
+	controllers []string
+	path        string
+}
+
+// Read gets the limits of the cgroup of this process, from procfs mounted at procRoot.
+func Read(procRoot string) (*Limits, error) {
+	f, err := os.Open(filepath.Join(procRoot, "self", "cgroup"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	entries, err := parseCgroup(f)
+
+	if err != nil {
+		return nil, err
+	}
+
+	mountList, err := mounts.ReadMountInfo(procRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return readLimits(entries, mountList), nil
+}
+
+// parseCgroup reads /proc/<pid>/cgroup, whose lines are "hierarchy-ID:controller-list:cgroup-path".
+// For cgroup v2, the line is "0::path".
+func parseCgroup(r io.Reader) ([]entry, error) {
+	var entries []entry
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), ":", 3)
+
+		if len(fields) != 3 {
+			continue
+		}
+
+		e := entry{id: fields[0], path: fields[2]}
+
+		if fields[1] != "" {
+			e.controllers = strings.Split(fields[1], ",")
+		}
+
+		entries = append(entries, e)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		return nil, errors.New("process is not in any cgroup")
+	}
+
+	return entries, nil
+}
+
+// hierarchy is where a controller's files for this process are.
+type hierarchy struct {
+	v2 bool
+
+	// dir is the directory of the cgroup of the process
+	dir string
+
+	// top is the directory of the root of the hierarchy visible to the process
+	top string
+
+	path string
+}
+
+// find locates the hierarchy that has controller, preferring v1 when the controller is attached to it.
+func find(entries []entry, mountList []mounts.Mount, controller string) (*hierarchy, bool) {
+	for _, e := range entries {
+		if !contains(e.controllers, controller) {
+			continue
+		}
+
+		for i := range mountList {
+			m := &mountList[i]
+
+			if m.FSType == "cgroup" && contains(m.Options, controller) {
+				return locate(m, e.path, false), true
+			}
+		}
+	}
+
+	for _, e := range entries {
+		if e.id != "0" || len(e.controllers) > 0 {
+			continue
+		}
+
+		for i := range mountList {
+			m := &mountList[i]
+
+			if m.FSType == "cgroup2" {
+				h := locate(m, e.path, true)
+
+				// A controller is only available in v2 if it is not attached to a v1 hierarchy
+				controllers, err := os.ReadFile(filepath.Join(h.top, "cgroup.controllers"))
+
+				if err != nil || !contains(strings.Fields(string(controllers)), controller) {
+					return nil, false
+				}
+
+				return h, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// locate finds the directory of the cgroup at path in the hierarchy mounted by m.
+func locate(m *mounts.Mount, path string, v2 bool) *hierarchy {
+	h := &hierarchy{v2: v2, top: m.MountPoint, path: path}
+
+	// If the mount is of a subtree, as in a container, paths are relative to it
+	rel := path
+
+	if m.Root != "" && m.Root != "/" && (rel == m.Root || strings.HasPrefix(rel, m.Root+"/")) {
+		rel = strings.TrimPrefix(rel, m.Root)
+	}
+
+	h.dir = filepath.Join(m.MountPoint, filepath.FromSlash(rel))
+
+	// In a container without its own cgroup namespace, the path is that on the host,
+	// but only the cgroup of the container is mounted
+	if _, err := os.Stat(h.dir); err != nil {
+		h.dir = m.MountPoint
+	}
+
+	return h
+}
+
+func readLimits(entries []entry, mountList []mounts.Mount) *Limits {
+	l := &Limits{Version: 2, Path: entries[0].path}
+
+	memory, hasMemory := find(entries, mountList, "memory")
+	cpu, hasCPU := find(entries, mountList, "cpu")
+	pids, hasPids := find(entries, mountList, "pids")
+
+	if (hasMemory && !memory.v2) || (hasCPU && !cpu.v2) {
+		l.Version = 1
+	}
+
+	for _, e := range entries {
+		if e.id == "0" && len(e.controllers) == 0 {
+			l.Path = e.path
+		}
+	}
+
+	if hasMemory {
+		l.Path = memory.path
+
+		if memory.v2 {
+			l.MemoryMax = readLimit(memory.dir, "memory.max")
+			l.MemoryHigh = readLimit(memory.dir, "memory.high")
+		} else {
+			l.MemoryMax = v1Memory(readLimit(memory.dir, "memory.limit_in_bytes"))
+		}
+
+		memory.walk(func(dir string) {
+			limit := readLimit(dir, "memory.max")
+
+			if !memory.v2 {
+				limit = v1Memory(readLimit(dir, "memory.limit_in_bytes"))
+			}
+
+			if limit > 0 && (l.EffectiveMemory == 0 || limit < l.EffectiveMemory) {
+				l.EffectiveMemory = limit
+			}
+		})
+	}
+
+	if hasCPU {
+		l.CPUQuota, l.CPUPeriod = cpu.readCPU(cpu.dir)
+
+		cpu.walk(func(dir string) {
+			quota, period := cpu.readCPU(dir)
+
+			if quota <= 0 || period <= 0 {
+				return
+			}
+
+			if limit := float64(quota) / float64(period); l.EffectiveCPUs == 0 || limit < l.EffectiveCPUs {
+				l.EffectiveCPUs = limit
+			}
+		})
+	}
+
+	if hasPids {
+		l.PidsMax = readLimit(pids.dir, "pids.max")
+	}
+
+	return l
+}
+
+// walk calls fn for the directory of the cgroup and each of its ancestors.
+func (h *hierarchy) walk(fn func(dir string)) {
+	for dir := h.dir; ; dir = filepath.Dir(dir) {
+		fn(dir)
+
+		if dir == h.top || len(dir) <= len(h.top) {
+			return
+		}
+	}
+}
+
+// readCPU gets the CPU quota and period of the cgroup in dir.
+func (h *hierarchy) readCPU(dir string) (int64, int64) {
+	if !h.v2 {
+		quota := readLimit(dir, "cpu.cfs_quota_us")
+
+		if quota < 0 {
+			quota = 0
+		}
+
+		return quota, readLimit(dir, "cpu.cfs_period_us")
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, "cpu.max"))
+
+	if err != nil {
+		return 0, 0
+	}
+
+	// "max 100000" or "50000 100000"
+	fields := strings.Fields(string(b))
+
+	if len(fields) != 2 {
+		return 0, 0
+	}
+
+	quota, _ := parseLimit(fields[0])
+	period, _ := parseLimit(fields[1])
+
+	return quota, period
+}
+
+// readLimit reads a file holding a number or "max". It returns 0 if there is no limit or the file cannot be read.
+func readLimit(dir, name string) int64 {
+	b, err := os.ReadFile(filepath.Join(dir, name))
+
+	if err != nil {
+		return 0
+	}
+
+	n, _ := parseLimit(strings.TrimSpace(string(b)))
+
+	return n
+}
+
+func parseLimit(s string) (int64, error) {
+	if s == "max" {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid limit %q", s)
+	}
+
+	return n, nil
+}
+
+func v1Memory(n int64) int64 {
+	if n >= v1MemoryUnlimited {
+		return 0
+	}
+
+	return n
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,125 @@
+package cgroup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRoot writes the files of a fake procfs and cgroup filesystems under a
+// temporary directory. mountinfo is formatted with the directory.
+func fakeRoot(t *testing.T, cgroup, mountinfo string, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	files["proc/self/cgroup"] = cgroup
+	files["proc/self/mountinfo"] = fmt.Sprintf(mountinfo, dir)
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	return dir
+}
+
+func TestReadV2(t *testing.T) {
+	root := fakeRoot(t,
+		"0::/user.slice/app.scope\n",
+		"30 20 0:26 / %s/cg rw,nosuid,nodev,noexec,relatime shared:4 - cgroup2 cgroup2 rw,nsdelegate\n",
+		map[string]string{
+			"cg/cgroup.controllers":               "cpuset cpu io memory pids\n",
+			"cg/user.slice/cpu.max":               "200000 100000\n",
+			"cg/user.slice/memory.max":            "4294967296\n",
+			"cg/user.slice/app.scope/cpu.max":     "max 100000\n",
+			"cg/user.slice/app.scope/memory.max":  "max\n",
+			"cg/user.slice/app.scope/memory.high": "3221225472\n",
+			"cg/user.slice/app.scope/pids.max":    "100\n",
+		})
+
+	l, err := Read(filepath.Join(root, "proc"))
+	require.NoError(t, err)
+	require.Equal(t, &Limits{
+		Version:         2,
+		Path:            "/user.slice/app.scope",
+		CPUQuota:        0,
+		CPUPeriod:       100000,
+		MemoryMax:       0,
+		MemoryHigh:      3221225472,
+		PidsMax:         100,
+		EffectiveCPUs:   2,
+		EffectiveMemory: 4294967296,
+	}, l)
+}
+
+func TestReadV1Container(t *testing.T) {
+	// Docker without a cgroup namespace: the path is that on the host, and the cgroup of the container is mounted
+	root := fakeRoot(t,
+		"12:pids:/docker/abc\n4:memory:/docker/abc\n3:cpu,cpuacct:/docker/abc\n1:name=systemd:/docker/abc\n",
+		`40 30 0:35 /docker/abc %[1]s/cpu,cpuacct ro,nosuid,nodev,noexec,relatime - cgroup cgroup rw,cpu,cpuacct
+41 30 0:36 /docker/abc %[1]s/memory ro,nosuid,nodev,noexec,relatime - cgroup cgroup rw,memory
+42 30 0:37 /docker/abc %[1]s/pids ro,nosuid,nodev,noexec,relatime - cgroup cgroup rw,pids
+`,
+		map[string]string{
+			"cpu,cpuacct/cpu.cfs_quota_us":  "150000\n",
+			"cpu,cpuacct/cpu.cfs_period_us": "100000\n",
+			"memory/memory.limit_in_bytes":  "536870912\n",
+			"pids/pids.max":                 "max\n",
+		})
+
+	l, err := Read(filepath.Join(root, "proc"))
+	require.NoError(t, err)
+	require.Equal(t, &Limits{
+		Version:         1,
+		Path:            "/docker/abc",
+		CPUQuota:        150000,
+		CPUPeriod:       100000,
+		MemoryMax:       536870912,
+		EffectiveCPUs:   1.5,
+		EffectiveMemory: 536870912,
+	}, l)
+}
+
+func TestReadV1Hybrid(t *testing.T) {
+	// cgroup v1 controllers, with an empty unified hierarchy
+	root := fakeRoot(t,
+		"4:memory:/session\n1:cpu:/\n0::/\n",
+		`33 32 0:29 / %[1]s/cpu rw,relatime - cgroup cgroup rw,cpu
+36 32 0:32 / %[1]s/memory rw,relatime - cgroup cgroup rw,memory
+42 32 0:38 / %[1]s/unified rw,relatime - cgroup2 cgroup2 rw
+`,
+		map[string]string{
+			"cpu/cpu.cfs_quota_us":                 "-1\n",
+			"cpu/cpu.cfs_period_us":                "100000\n",
+			"memory/memory.limit_in_bytes":         "9223372036854771712\n",
+			"memory/session/memory.limit_in_bytes": "9223372036854771712\n",
+			"unified/cgroup.controllers":           "\n",
+		})
+
+	l, err := Read(filepath.Join(root, "proc"))
+	require.NoError(t, err)
+	require.Equal(t, &Limits{
+		Version:   1,
+		Path:      "/session",
+		CPUPeriod: 100000,
+	}, l)
+}
+
+func TestReadLocal(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("cgroups are only available on linux")
+	}
+
+	if _, err := os.Stat("/proc/self/cgroup"); err != nil {
+		t.Skip("cgroups are not available")
+	}
+
+	l, err := Read(DefaultProcRoot)
+	require.NoError(t, err)
+	require.NotEmpty(t, l.Path)
+}
@@ -0,0 +1,318 @@
+package cienv
+
+import (
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Providers that can be detected.
+const (
+	GitHubActions  = "github_actions"
+	GitLab         = "gitlab"
+	AzurePipelines = "azure_pipelines"
+	CircleCI       = "circleci"
+	Buildkite      = "buildkite"
+	Bitbucket      = "bitbucket"
+	Jenkins        = "jenkins"
+	TeamCity       = "teamcity"
+	Atlantis       = "atlantis"
+	TerraformCloud = "terraform_cloud"
+)
+
+// Build describes the CI job terraform is running in. Fields that the
+// provider does not set are empty.
+type Build struct {
+	// IsCI is true if running in a CI job, even if the provider is not known
+	IsCI bool
+
+	// Provider is one of the constants above, or empty
+	Provider string
+
+	// Repository is e.g. "owner/name", or for some providers the clone URL
+	Repository string
+
+	Branch    string
+	CommitSHA string
+
+	// PullRequest is the number of the pull or merge request, or 0
+	PullRequest int
+
+	// JobURL links to the job, pipeline or build
+	JobURL string
+
+	// Actor is the user who started the job
+	Actor string
+}
+
+// detectors are tried in order. Each returns nil if it does not apply.
+var detectors = []func(getenv func(string) string) *Build{
+	githubActions,
+	gitlab,
+	azurePipelines,
+	circleCI,
+	buildkite,
+	bitbucket,
+	jenkins,
+	teamCity,
+	atlantis,
+	terraformCloud,
+}
+
+// Detect finds the CI provider from the environment, read with getenv.
+func Detect(getenv func(string) string) *Build {
+	for _, detect := range detectors {
+		if b := detect(getenv); b != nil {
+			b.IsCI = true
+			return b
+		}
+	}
+
+	// Most providers set CI, as do many that are not detected
+	if ci := strings.ToLower(getenv("CI")); ci != "" && ci != "false" && ci != "0" {
+		return &Build{IsCI: true}
+	}
+
+	return &Build{}
+}
+
+func githubActions(getenv func(string) string) *Build {
+	if getenv("GITHUB_ACTIONS") != "true" {
+		return nil
+	}
+
+	b := &Build{
+		Provider:   GitHubActions,
+		Repository: getenv("GITHUB_REPOSITORY"),
+		Branch:     getenv("GITHUB_HEAD_REF"),
+		CommitSHA:  getenv("GITHUB_SHA"),
+		Actor:      getenv("GITHUB_ACTOR"),
+	}
+
+	if b.Branch == "" && getenv("GITHUB_REF_TYPE") == "branch" {
+		b.Branch = getenv("GITHUB_REF_NAME")
+	}
+
+	// Pull request runs are of refs/pull/<number>/merge
+	if ref := getenv("GITHUB_REF"); strings.HasPrefix(ref, "refs/pull/") {
+		b.PullRequest = number(strings.Split(ref, "/")[2])
+	}
+
+	if server, run := getenv("GITHUB_SERVER_URL"), getenv("GITHUB_RUN_ID"); server != "" && b.Repository != "" && run != "" {
+		b.JobURL = server + "/" + b.Repository + "/actions/runs/" + run
+	}
+
+	return b
+}
+
+func gitlab(getenv func(string) string) *Build {
+	if getenv("GITLAB_CI") != "true" {
+		return nil
+	}
+
+	return &Build{
+		Provider:    GitLab,
+		Repository:  getenv("CI_PROJECT_PATH"),
+		Branch:      first(getenv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME"), getenv("CI_COMMIT_BRANCH")),
+		CommitSHA:   getenv("CI_COMMIT_SHA"),
+		PullRequest: number(getenv("CI_MERGE_REQUEST_IID")),
+		JobURL:      getenv("CI_JOB_URL"),
+		Actor:       getenv("GITLAB_USER_LOGIN"),
+	}
+}
+
+func azurePipelines(getenv func(string) string) *Build {
+	if !strings.EqualFold(getenv("TF_BUILD"), "true") {
+		return nil
+	}
+
+	b := &Build{
+		Provider:   AzurePipelines,
+		Repository: getenv("BUILD_REPOSITORY_NAME"),
+		Branch:     strings.TrimPrefix(first(getenv("SYSTEM_PULLREQUEST_SOURCEBRANCH"), getenv("BUILD_SOURCEBRANCH")), "refs/heads/"),
+		CommitSHA:  getenv("BUILD_SOURCEVERSION"),
+
+		// The number is set for GitHub repositories, the ID for Azure Repos
+		PullRequest: number(first(getenv("SYSTEM_PULLREQUEST_PULLREQUESTNUMBER"), getenv("SYSTEM_PULLREQUEST_PULLREQUESTID"))),
+		Actor:       getenv("BUILD_REQUESTEDFOR"),
+	}
+
+	if collection, project, id := getenv("SYSTEM_COLLECTIONURI"), getenv("SYSTEM_TEAMPROJECT"), getenv("BUILD_BUILDID"); collection != "" && project != "" && id != "" {
+		b.JobURL = strings.TrimSuffix(collection, "/") + "/" + project + "/_build/results?buildId=" + id
+	}
+
+	return b
+}
+
+func circleCI(getenv func(string) string) *Build {
+	if getenv("CIRCLECI") != "true" {
+		return nil
+	}
+
+	b := &Build{
+		Provider:    CircleCI,
+		Branch:      getenv("CIRCLE_BRANCH"),
+		CommitSHA:   getenv("CIRCLE_SHA1"),
+		PullRequest: number(getenv("CIRCLE_PR_NUMBER")),
+		JobURL:      getenv("CIRCLE_BUILD_URL"),
+		Actor:       getenv("CIRCLE_USERNAME"),
+	}
+
+	if owner, name := getenv("CIRCLE_PROJECT_USERNAME"), getenv("CIRCLE_PROJECT_REPONAME"); owner != "" && name != "" {
+		b.Repository = owner + "/" + name
+	}
+
+	// CIRCLE_PR_NUMBER is only set for forks; the URL is set for all pull requests
+	if pr := getenv("CIRCLE_PULL_REQUEST"); b.PullRequest == 0 && pr != "" {
+		b.PullRequest = number(path.Base(pr))
+	}
+
+	return b
+}
+
+func buildkite(getenv func(string) string) *Build {
+	if getenv("BUILDKITE") != "true" {
+		return nil
+	}
+
+	b := &Build{
+		Provider:   Buildkite,
+		Repository: getenv("BUILDKITE_REPO"),
+		Branch:     getenv("BUILDKITE_BRANCH"),
+		CommitSHA:  getenv("BUILDKITE_COMMIT"),
+
+		// "false" if the build is not of a pull request
+		PullRequest: number(getenv("BUILDKITE_PULL_REQUEST")),
+		JobURL:      getenv("BUILDKITE_BUILD_URL"),
+		Actor:       getenv("BUILDKITE_BUILD_CREATOR"),
+	}
+
+	if job := getenv("BUILDKITE_JOB_ID"); b.JobURL != "" && job != "" {
+		b.JobURL += "#" + job
+	}
+
+	return b
+}
+
+func bitbucket(getenv func(string) string) *Build {
+	if getenv("BITBUCKET_BUILD_NUMBER") == "" {
+		return nil
+	}
+
+	b := &Build{
+		Provider:    Bitbucket,
+		Repository:  getenv("BITBUCKET_REPO_FULL_NAME"),
+		Branch:      getenv("BITBUCKET_BRANCH"),
+		CommitSHA:   getenv("BITBUCKET_COMMIT"),
+		PullRequest: number(getenv("BITBUCKET_PR_ID")),
+
+		// Only the UUID of the user is available
+		Actor: getenv("BITBUCKET_STEP_TRIGGERER_UUID"),
+	}
+
+	if origin := getenv("BITBUCKET_GIT_HTTP_ORIGIN"); origin != "" {
+		b.JobURL = origin + "/addon/pipelines/home#!/results/" + getenv("BITBUCKET_BUILD_NUMBER")
+	}
+
+	return b
+}
+
+func jenkins(getenv func(string) string) *Build {
+	if getenv("JENKINS_URL") == "" || getenv("BUILD_ID") == "" {
+		return nil
+	}
+
+	// Multibranch pipelines set BRANCH_NAME, and CHANGE_* for pull requests. The git plugin sets GIT_*.
+	return &Build{
+		Provider:    Jenkins,
+		Repository:  getenv("GIT_URL"),
+		Branch:      strings.TrimPrefix(first(getenv("CHANGE_BRANCH"), getenv("BRANCH_NAME"), getenv("GIT_BRANCH")), "origin/"),
+		CommitSHA:   getenv("GIT_COMMIT"),
+		PullRequest: number(getenv("CHANGE_ID")),
+		JobURL:      getenv("BUILD_URL"),
+		Actor:       first(getenv("BUILD_USER_ID"), getenv("CHANGE_AUTHOR")),
+	}
+}
+
+func teamCity(getenv func(string) string) *Build {
+	if getenv("TEAMCITY_VERSION") == "" {
+		return nil
+	}
+
+	// TeamCity passes the repository, branch, build URL and triggering user only as build
+	// parameters, which reach the environment only if configured as env. parameters, so
+	// those are left empty.
+	return &Build{
+		Provider:  TeamCity,
+		CommitSHA: getenv("BUILD_VCS_NUMBER"),
+	}
+}
+
+func atlantis(getenv func(string) string) *Build {
+	// BASE_REPO_NAME, PULL_NUM and the like are too generic to identify Atlantis on their own
+	if getenv("ATLANTIS_TERRAFORM_VERSION") == "" {
+		return nil
+	}
+
+	b := &Build{
+		Provider:    Atlantis,
+		Branch:      getenv("HEAD_BRANCH_NAME"),
+		CommitSHA:   getenv("HEAD_COMMIT"),
+		PullRequest: number(getenv("PULL_NUM")),
+
+		// Atlantis has no page for a job, so link the pull request where it comments
+		JobURL: getenv("PULL_URL"),
+		Actor:  first(getenv("USER_NAME"), getenv("PULL_AUTHOR")),
+	}
+
+	if owner, name := getenv("BASE_REPO_OWNER"), getenv("BASE_REPO_NAME"); owner != "" && name != "" {
+		b.Repository = owner + "/" + name
+	}
+
+	return b
+}
+
+func terraformCloud(getenv func(string) string) *Build {
+	if getenv("TFC_RUN_ID") == "" {
+		return nil
+	}
+
+	// Branch and commit are only set for workspaces connected to a VCS. Runs are not given
+	// the repository, pull request or user that queued them, so those are left empty.
+	b := &Build{
+		Provider:  TerraformCloud,
+		Branch:    getenv("TFC_CONFIGURATION_VERSION_GIT_BRANCH"),
+		CommitSHA: getenv("TFC_CONFIGURATION_VERSION_GIT_COMMIT_SHA"),
+	}
+
+	// TFC_WORKSPACE_SLUG is "<organization>/<workspace>". Agents set TFC_ADDRESS, e.g. for
+	// Terraform Enterprise, otherwise runs are on HCP Terraform.
+	if org, workspace, ok := strings.Cut(getenv("TFC_WORKSPACE_SLUG"), "/"); ok {
+		address := strings.TrimSuffix(first(getenv("TFC_ADDRESS"), "https://app.terraform.io"), "/")
+		b.JobURL = address + "/app/" + org + "/workspaces/" + first(getenv("TFC_WORKSPACE_NAME"), workspace) + "/runs/" + getenv("TFC_RUN_ID")
+	}
+
+	return b
+}
+
+// first returns the first value that is not empty.
+func first(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// number parses a pull request number, returning 0 if there is none.
+func number(s string) int {
+	n, err := strconv.Atoi(s)
+
+	if err != nil || n < 0 {
+		return 0
+	}
+
+	return n
+}
@@ -0,0 +1,256 @@
+package cienv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func env(vars map[string]string) func(string) string {
+	return func(key string) string {
+		return vars[key]
+	}
+}
+
+func TestDetect(t *testing.T) {
+	for name, tc := range map[string]struct {
+		vars     map[string]string
+		expected Build
+	}{
+		"none": {
+			vars:     map[string]string{"HOME": "/home/user"},
+			expected: Build{},
+		},
+		"unknown": {
+			vars:     map[string]string{"CI": "true"},
+			expected: Build{IsCI: true},
+		},
+		"github push": {
+			vars: map[string]string{
+				"CI":                "true",
+				"GITHUB_ACTIONS":    "true",
+				"GITHUB_REPOSITORY": "octo/infra",
+				"GITHUB_REF":        "refs/heads/main",
+				"GITHUB_REF_NAME":   "main",
+				"GITHUB_REF_TYPE":   "branch",
+				"GITHUB_SHA":        "4f3c2b1",
+				"GITHUB_ACTOR":      "octocat",
+				"GITHUB_SERVER_URL": "https://github.com",
+				"GITHUB_RUN_ID":     "1658821493",
+			},
+			expected: Build{
+				IsCI:       true,
+				Provider:   GitHubActions,
+				Repository: "octo/infra",
+				Branch:     "main",
+				CommitSHA:  "4f3c2b1",
+				JobURL:     "https://github.com/octo/infra/actions/runs/1658821493",
+				Actor:      "octocat",
+			},
+		},
+		"github pull request": {
+			vars: map[string]string{
+				"GITHUB_ACTIONS":    "true",
+				"GITHUB_REPOSITORY": "octo/infra",
+				"GITHUB_REF":        "refs/pull/42/merge",
+				"GITHUB_REF_NAME":   "42/merge",
+				"GITHUB_HEAD_REF":   "feature/dns",
+				"GITHUB_SHA":        "4f3c2b1",
+			},
+			expected: Build{
+				IsCI:        true,
+				Provider:    GitHubActions,
+				Repository:  "octo/infra",
+				Branch:      "feature/dns",
+				CommitSHA:   "4f3c2b1",
+				PullRequest: 42,
+			},
+		},
+		"gitlab merge request": {
+			vars: map[string]string{
+				"GITLAB_CI":                           "true",
+				"CI_PROJECT_PATH":                     "group/infra",
+				"CI_MERGE_REQUEST_SOURCE_BRANCH_NAME": "feature/dns",
+				"CI_COMMIT_SHA":                       "abc123",
+				"CI_MERGE_REQUEST_IID":                "7",
+				"CI_JOB_URL":                          "https://gitlab.example.com/group/infra/-/jobs/99",
+				"GITLAB_USER_LOGIN":                   "jdoe",
+			},
+			expected: Build{
+				IsCI:        true,
+				Provider:    GitLab,
+				Repository:  "group/infra",
+				Branch:      "feature/dns",
+				CommitSHA:   "abc123",
+				PullRequest: 7,
+				JobURL:      "https://gitlab.example.com/group/infra/-/jobs/99",
+				Actor:       "jdoe",
+			},
+		},
+		"azure pipelines": {
+			vars: map[string]string{
+				"TF_BUILD":              "True",
+				"BUILD_REPOSITORY_NAME": "infra",
+				"BUILD_SOURCEBRANCH":    "refs/heads/release/1.2",
+				"BUILD_SOURCEVERSION":   "def456",
+				"BUILD_REQUESTEDFOR":    "Jane Doe",
+				"SYSTEM_COLLECTIONURI":  "https://dev.azure.com/org/",
+				"SYSTEM_TEAMPROJECT":    "platform",
+				"BUILD_BUILDID":         "123",
+			},
+			expected: Build{
+				IsCI:       true,
+				Provider:   AzurePipelines,
+				Repository: "infra",
+				Branch:     "release/1.2",
+				CommitSHA:  "def456",
+				JobURL:     "https://dev.azure.com/org/platform/_build/results?buildId=123",
+				Actor:      "Jane Doe",
+			},
+		},
+		"circleci": {
+			vars: map[string]string{
+				"CIRCLECI":                "true",
+				"CIRCLE_PROJECT_USERNAME": "octo",
+				"CIRCLE_PROJECT_REPONAME": "infra",
+				"CIRCLE_BRANCH":           "feature/dns",
+				"CIRCLE_SHA1":             "abc",
+				"CIRCLE_PULL_REQUEST":     "https://github.com/octo/infra/pull/12",
+				"CIRCLE_BUILD_URL":        "https://circleci.com/gh/octo/infra/5",
+				"CIRCLE_USERNAME":         "octocat",
+			},
+			expected: Build{
+				IsCI:        true,
+				Provider:    CircleCI,
+				Repository:  "octo/infra",
+				Branch:      "feature/dns",
+				CommitSHA:   "abc",
+				PullRequest: 12,
+				JobURL:      "https://circleci.com/gh/octo/infra/5",
+				Actor:       "octocat",
+			},
+		},
+		"buildkite": {
+			vars: map[string]string{
+				"BUILDKITE":               "true",
+				"BUILDKITE_REPO":          "git@github.com:octo/infra.git",
+				"BUILDKITE_BRANCH":        "main",
+				"BUILDKITE_COMMIT":        "abc",
+				"BUILDKITE_PULL_REQUEST":  "false",
+				"BUILDKITE_BUILD_URL":     "https://buildkite.com/octo/infra/builds/8",
+				"BUILDKITE_JOB_ID":        "0188-aa",
+				"BUILDKITE_BUILD_CREATOR": "Jane Doe",
+			},
+			expected: Build{
+				IsCI:       true,
+				Provider:   Buildkite,
+				Repository: "git@github.com:octo/infra.git",
+				Branch:     "main",
+				CommitSHA:  "abc",
+				JobURL:     "https://buildkite.com/octo/infra/builds/8#0188-aa",
+				Actor:      "Jane Doe",
+			},
+		},
+		"bitbucket": {
+			vars: map[string]string{
+				"BITBUCKET_BUILD_NUMBER":    "31",
+				"BITBUCKET_REPO_FULL_NAME":  "team/infra",
+				"BITBUCKET_BRANCH":          "main",
+				"BITBUCKET_COMMIT":          "abc",
+				"BITBUCKET_PR_ID":           "3",
+				"BITBUCKET_GIT_HTTP_ORIGIN": "http://bitbucket.org/team/infra",
+			},
+			expected: Build{
+				IsCI:        true,
+				Provider:    Bitbucket,
+				Repository:  "team/infra",
+				Branch:      "main",
+				CommitSHA:   "abc",
+				PullRequest: 3,
+				JobURL:      "http://bitbucket.org/team/infra/addon/pipelines/home#!/results/31",
+			},
+		},
+		"jenkins": {
+			vars: map[string]string{
+				"JENKINS_URL": "https://jenkins.example.com/",
+				"BUILD_ID":    "17",
+				"BUILD_URL":   "https://jenkins.example.com/job/infra/17/",
+				"GIT_URL":     "https://github.com/octo/infra.git",
+				"GIT_BRANCH":  "origin/main",
+				"GIT_COMMIT":  "abc",
+			},
+			expected: Build{
+				IsCI:       true,
+				Provider:   Jenkins,
+				Repository: "https://github.com/octo/infra.git",
+				Branch:     "main",
+				CommitSHA:  "abc",
+				JobURL:     "https://jenkins.example.com/job/infra/17/",
+			},
+		},
+		"teamcity": {
+			vars: map[string]string{
+				"TEAMCITY_VERSION": "2023.05",
+				"BUILD_VCS_NUMBER": "abc",
+			},
+			expected: Build{IsCI: true, Provider: TeamCity, CommitSHA: "abc"},
+		},
+		"atlantis": {
+			vars: map[string]string{
+				"ATLANTIS_TERRAFORM_VERSION": "1.6.2",
+				"BASE_REPO_OWNER":            "octo",
+				"BASE_REPO_NAME":             "infra",
+				"HEAD_BRANCH_NAME":           "feature/dns",
+				"HEAD_COMMIT":                "abc",
+				"PULL_NUM":                   "9",
+				"PULL_URL":                   "https://github.com/octo/infra/pull/9",
+				"USER_NAME":                  "octocat",
+			},
+			expected: Build{
+				IsCI:        true,
+				Provider:    Atlantis,
+				Repository:  "octo/infra",
+				Branch:      "feature/dns",
+				CommitSHA:   "abc",
+				PullRequest: 9,
+				JobURL:      "https://github.com/octo/infra/pull/9",
+				Actor:       "octocat",
+			},
+		},
+		"terraform cloud": {
+			vars: map[string]string{
+				"TFC_RUN_ID":                               "run-abc",
+				"TFC_CONFIGURATION_VERSION_GIT_BRANCH":     "main",
+				"TFC_CONFIGURATION_VERSION_GIT_COMMIT_SHA": "abc",
+			},
+			expected: Build{IsCI: true, Provider: TerraformCloud, Branch: "main", CommitSHA: "abc"},
+		},
+		"terraform cloud run url": {
+			vars: map[string]string{
+				"TFC_RUN_ID":         "run-abc",
+				"TFC_WORKSPACE_NAME": "dns",
+				"TFC_WORKSPACE_SLUG": "octo/dns",
+			},
+			expected: Build{IsCI: true, Provider: TerraformCloud, JobURL: "https://app.terraform.io/app/octo/workspaces/dns/runs/run-abc"},
+		},
+		"terraform enterprise run url": {
+			vars: map[string]string{
+				"TFC_RUN_ID":         "run-abc",
+				"TFC_WORKSPACE_SLUG": "octo/dns",
+				"TFC_ADDRESS":        "https://tfe.example.com/",
+			},
+			expected: Build{IsCI: true, Provider: TerraformCloud, JobURL: "https://tfe.example.com/app/octo/workspaces/dns/runs/run-abc"},
+		},
+		"atlantis variables without atlantis": {
+			vars: map[string]string{
+				"BASE_REPO_NAME": "infra",
+				"PULL_NUM":       "9",
+			},
+			expected: Build{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, &tc.expected, Detect(env(tc.vars)))
+		})
+	}
+}
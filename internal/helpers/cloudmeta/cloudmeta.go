@@ -0,0 +1,419 @@
+package cloudmeta
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Clouds whose metadata services can be queried.
+const (
+	AWS          = "aws"
+	GCP          = "gcp"
+	Azure        = "azure"
+	OCI          = "oci"
+	DigitalOcean = "digitalocean"
+)
+
+// Providers are all the clouds, in the order they are preferred if more than one answers.
+var Providers = []string{AWS, GCP, Azure, OCI, DigitalOcean}
+
+// DefaultEndpoints are the base URLs of the metadata services.
+var DefaultEndpoints = map[string]string{
+	AWS:          "http://169.254.169.254",
+	GCP:          "http://metadata.google.internal",
+	Azure:        "http://169.254.169.254",
+	OCI:          "http://169.254.169.254",
+	DigitalOcean: "http://169.254.169.254",
+}
+
+// ErrNotFound is returned when no metadata service answers.
+var ErrNotFound = errors.New("no cloud metadata service found")
+
+// Instance describes the virtual machine. Fields the cloud does not provide are empty.
+type Instance struct {
+	Provider     string
+	InstanceID   string
+	Name         string
+	Region       string
+	Zone         string
+	Account      string
+	InstanceType string
+	PrivateIP    string
+	PublicIP     string
+}
+
+// Client queries metadata services.
+type Client struct {
+	// HTTP defaults to a client made by NewHTTPClient
+	HTTP *http.Client
+
+	// Endpoints override DefaultEndpoints, e.g. to use a local fake
+	Endpoints map[string]string
+}
+
+// NewHTTPClient makes a client for metadata services, which are only reachable from
+// the instance itself, so proxies in the environment are not used. Redirects are not
+// followed, so that the credentials and identity of the instance are not sent elsewhere.
+func NewHTTPClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+var defaultHTTPClient = NewHTTPClient()
+
+type getter func(ctx context.Context, c *Client, base string) (*Instance, error)
+
+var getters = map[string]getter{
+	AWS:          getAWS,
+	GCP:          getGCP,
+	Azure:        getAzure,
+	OCI:          getOCI,
+	DigitalOcean: getDigitalOcean,
+}
+
+// Detect queries the metadata services of the given providers at once, and
+// returns the instance from the first in order that answers. The caller
+// should set a deadline on ctx, as on other networks the requests may hang.
+func (c *Client) Detect(ctx context.Context, providers []string) (*Instance, error) {
+	results := make([]*Instance, len(providers))
+	errs := make([]error, len(providers))
+
+	var wg sync.WaitGroup
+
+	for i, p := range providers {
+		get, ok := getters[p]
+
+		if !ok {
+			return nil, fmt.Errorf("unsupported provider %q", p)
+		}
+
+		wg.Add(1)
+
+		go func(i int, p string) {
+			defer wg.Done()
+			results[i], errs[i] = get(ctx, c, c.endpoint(p))
+		}(i, p)
+	}
+
+	wg.Wait()
+
+	for i, p := range providers {
+		if results[i] != nil {
+			results[i].Provider = p
+			return results[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %w", ErrNotFound, errors.Join(errs...))
+}
+
+func (c *Client) endpoint(provider string) string {
+	if e, ok := c.Endpoints[provider]; ok && e != "" {
+		return strings.TrimSuffix(e, "/")
+	}
+
+	return DefaultEndpoints[provider]
+}
+
+// request makes an HTTP request and returns the body if the status is 200.
+func (c *Client) request(ctx context.Context, method, url string, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	client := c.HTTP
+
+	if client == nil {
+		client = defaultHTTPClient
+	}
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	// Metadata documents are small; anything larger is not a metadata service
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s: %s", method, url, resp.Status)
+	}
+
+	return body, nil
+}
+
+func (c *Client) getJSON(ctx context.Context, url string, headers map[string]string, v any) error {
+	body, err := c.request(ctx, http.MethodGet, url, headers)
+
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("GET %s: %w", url, err)
+	}
+
+	return nil
+}
+
+// getAWS uses IMDSv2, which requires a session token.
+func getAWS(ctx context.Context, c *Client, base string) (*Instance, error) {
+	token, err := c.request(ctx, http.MethodPut, base+"/latest/api/token", map[string]string{
+		"X-aws-ec2-metadata-token-ttl-seconds": "60",
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	headers := map[string]string{"X-aws-ec2-metadata-token": string(token)}
+
+	var doc struct {
+		AccountID        string `json:"accountId"`
+		AvailabilityZone string `json:"availabilityZone"`
+		InstanceID       string `json:"instanceId"`
+		InstanceType     string `json:"instanceType"`
+		PrivateIP        string `json:"privateIp"`
+		Region           string `json:"region"`
+	}
+
+	if err := c.getJSON(ctx, base+"/latest/dynamic/instance-identity/document", headers, &doc); err != nil {
+		return nil, err
+	}
+
+	if doc.InstanceID == "" {
+		return nil, errors.New("aws: no instance ID in identity document")
+	}
+
+	i := &Instance{
+		InstanceID:   doc.InstanceID,
+		Region:       doc.Region,
+		Zone:         doc.AvailabilityZone,
+		Account:      doc.AccountID,
+		InstanceType: doc.InstanceType,
+		PrivateIP:    doc.PrivateIP,
+	}
+
+	// 404 if the instance has no public IP
+	if ip, err := c.request(ctx, http.MethodGet, base+"/latest/meta-data/public-ipv4", headers); err == nil {
+		i.PublicIP = strings.TrimSpace(string(ip))
+	}
+
+	if name, err := c.request(ctx, http.MethodGet, base+"/latest/meta-data/tags/instance/Name", headers); err == nil {
+		i.Name = strings.TrimSpace(string(name))
+	}
+
+	return i, nil
+}
+
+func getGCP(ctx context.Context, c *Client, base string) (*Instance, error) {
+	headers := map[string]string{"Metadata-Flavor": "Google"}
+
+	var doc struct {
+		ID                json.Number `json:"id"`
+		Name              string      `json:"name"`
+		Zone              string      `json:"zone"`
+		MachineType       string      `json:"machineType"`
+		NetworkInterfaces []struct {
+			IP            string `json:"ip"`
+			AccessConfigs []struct {
+				ExternalIP string `json:"externalIp"`
+			} `json:"accessConfigs"`
+		} `json:"networkInterfaces"`
+	}
+
+	if err := c.getJSON(ctx, base+"/computeMetadata/v1/instance/?recursive=true", headers, &doc); err != nil {
+		return nil, err
+	}
+
+	if doc.ID == "" {
+		return nil, errors.New("gcp: no instance ID in metadata")
+	}
+
+	// Zone and machine type are e.g. "projects/123456789/zones/us-central1-a"
+	i := &Instance{
+		InstanceID:   doc.ID.String(),
+		Name:         doc.Name,
+		Zone:         lastSegment(doc.Zone),
+		InstanceType: lastSegment(doc.MachineType),
+	}
+
+	if n := strings.LastIndex(i.Zone, "-"); n > 0 {
+		i.Region = i.Zone[:n]
+	}
+
+	if len(doc.NetworkInterfaces) > 0 {
+		i.PrivateIP = doc.NetworkInterfaces[0].IP
+
+		if len(doc.NetworkInterfaces[0].AccessConfigs) > 0 {
+			i.PublicIP = doc.NetworkInterfaces[0].AccessConfigs[0].ExternalIP
+		}
+	}
+
+	if project, err := c.request(ctx, http.MethodGet, base+"/computeMetadata/v1/project/project-id", headers); err == nil {
+		i.Account = strings.TrimSpace(string(project))
+	}
+
+	return i, nil
+}
+
+func getAzure(ctx context.Context, c *Client, base string) (*Instance, error) {
+	var doc struct {
+		Compute struct {
+			VMID           string `json:"vmId"`
+			Name           string `json:"name"`
+			Location       string `json:"location"`
+			Zone           string `json:"zone"`
+			SubscriptionID string `json:"subscriptionId"`
+			VMSize         string `json:"vmSize"`
+		} `json:"compute"`
+		Network struct {
+			Interface []struct {
+				IPv4 struct {
+					IPAddress []struct {
+						PrivateIPAddress string `json:"privateIpAddress"`
+						PublicIPAddress  string `json:"publicIpAddress"`
+					} `json:"ipAddress"`
+				} `json:"ipv4"`
+			} `json:"interface"`
+		} `json:"network"`
+	}
+
+	if err := c.getJSON(ctx, base+"/metadata/instance?api-version=2021-02-01", map[string]string{"Metadata": "true"}, &doc); err != nil {
+		return nil, err
+	}
+
+	if doc.Compute.VMID == "" {
+		return nil, errors.New("azure: no VM ID in metadata")
+	}
+
+	i := &Instance{
+		InstanceID:   doc.Compute.VMID,
+		Name:         doc.Compute.Name,
+		Region:       doc.Compute.Location,
+		Zone:         doc.Compute.Zone,
+		Account:      doc.Compute.SubscriptionID,
+		InstanceType: doc.Compute.VMSize,
+	}
+
+	if len(doc.Network.Interface) > 0 && len(doc.Network.Interface[0].IPv4.IPAddress) > 0 {
+		i.PrivateIP = doc.Network.Interface[0].IPv4.IPAddress[0].PrivateIPAddress
+		i.PublicIP = doc.Network.Interface[0].IPv4.IPAddress[0].PublicIPAddress
+	}
+
+	return i, nil
+}
+
+// getOCI uses version 2 of the metadata service. It does not report public IPs.
+func getOCI(ctx context.Context, c *Client, base string) (*Instance, error) {
+	headers := map[string]string{"Authorization": "Bearer Oracle"}
+
+	var doc struct {
+		ID                  string `json:"id"`
+		DisplayName         string `json:"displayName"`
+		CanonicalRegionName string `json:"canonicalRegionName"`
+		AvailabilityDomain  string `json:"availabilityDomain"`
+		CompartmentID       string `json:"compartmentId"`
+		Shape               string `json:"shape"`
+	}
+
+	if err := c.getJSON(ctx, base+"/opc/v2/instance/", headers, &doc); err != nil {
+		return nil, err
+	}
+
+	if doc.ID == "" {
+		return nil, errors.New("oci: no instance ID in metadata")
+	}
+
+	i := &Instance{
+		InstanceID:   doc.ID,
+		Name:         doc.DisplayName,
+		Region:       doc.CanonicalRegionName,
+		Zone:         doc.AvailabilityDomain,
+		Account:      doc.CompartmentID,
+		InstanceType: doc.Shape,
+	}
+
+	var vnics []struct {
+		PrivateIP string `json:"privateIp"`
+	}
+
+	if err := c.getJSON(ctx, base+"/opc/v2/vnics/", headers, &vnics); err == nil && len(vnics) > 0 {
+		i.PrivateIP = vnics[0].PrivateIP
+	}
+
+	return i, nil
+}
+
+// getDigitalOcean reads the droplet metadata. It has no zones, account or size.
+func getDigitalOcean(ctx context.Context, c *Client, base string) (*Instance, error) {
+	type address struct {
+		IPv4 struct {
+			IPAddress string `json:"ip_address"`
+		} `json:"ipv4"`
+	}
+
+	var doc struct {
+		DropletID  json.Number `json:"droplet_id"`
+		Hostname   string      `json:"hostname"`
+		Region     string      `json:"region"`
+		Interfaces struct {
+			Public  []address `json:"public"`
+			Private []address `json:"private"`
+		} `json:"interfaces"`
+	}
+
+	if err := c.getJSON(ctx, base+"/metadata/v1.json", nil, &doc); err != nil {
+		return nil, err
+	}
+
+	if doc.DropletID == "" {
+		return nil, errors.New("digitalocean: no droplet ID in metadata")
+	}
+
+	i := &Instance{
+		InstanceID: doc.DropletID.String(),
+		Name:       doc.Hostname,
+		Region:     doc.Region,
+	}
+
+	if len(doc.Interfaces.Public) > 0 {
+		i.PublicIP = doc.Interfaces.Public[0].IPv4.IPAddress
+	}
+
+	if len(doc.Interfaces.Private) > 0 {
+		i.PrivateIP = doc.Interfaces.Private[0].IPv4.IPAddress
+	}
+
+	return i, nil
+}
+
+func lastSegment(s string) string {
+	return s[strings.LastIndex(s, "/")+1:]
+}
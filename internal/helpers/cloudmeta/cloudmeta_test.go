@@ -0,0 +1,252 @@
+package cloudmeta
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAWS serves IMDSv2, rejecting requests without a token as EC2 does.
+func fakeAWS(mux *http.ServeMux) {
+	mux.HandleFunc("/latest/api/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.Header.Get("X-aws-ec2-metadata-token-ttl-seconds") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_, _ = w.Write([]byte("secret-token"))
+	})
+
+	authorized := func(handler http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-aws-ec2-metadata-token") != "secret-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			handler(w, r)
+		}
+	}
+
+	mux.HandleFunc("/latest/dynamic/instance-identity/document", authorized(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+  "accountId" : "123456789012",
+  "architecture" : "x86_64",
+  "availabilityZone" : "eu-west-2a",
+  "instanceId" : "i-0123456789abcdef0",
+  "instanceType" : "t3.micro",
+  "privateIp" : "10.0.1.23",
+  "region" : "eu-west-2"
+}`))
+	}))
+	mux.HandleFunc("/latest/meta-data/public-ipv4", authorized(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("203.0.113.10"))
+	}))
+}
+
+func fakeGCP(mux *http.ServeMux) {
+	flavored := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Metadata-Flavor") != "Google" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			w.Header().Set("Metadata-Flavor", "Google")
+			_, _ = w.Write([]byte(body))
+		}
+	}
+
+	mux.HandleFunc("/computeMetadata/v1/instance/", flavored(`{
+  "id": 4520031799277581759,
+  "name": "bastion",
+  "zone": "projects/123456789/zones/us-central1-a",
+  "machineType": "projects/123456789/machineTypes/e2-medium",
+  "networkInterfaces": [{"ip": "10.128.0.2", "accessConfigs": [{"externalIp": "198.51.100.7"}]}]
+}`))
+	mux.HandleFunc("/computeMetadata/v1/project/project-id", flavored("my-project"))
+}
+
+func fakeAzure(mux *http.ServeMux) {
+	mux.HandleFunc("/metadata/instance", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" || r.URL.Query().Get("api-version") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{
+  "compute": {"vmId": "02aab8a4-74ef-476e-8182-f6d2ba4166a6", "name": "bastion", "location": "westeurope", "zone": "1",
+              "subscriptionId": "8d10da13-8125-4ba9-a717-bf7490507b3d", "vmSize": "Standard_B2s"},
+  "network": {"interface": [{"ipv4": {"ipAddress": [{"privateIpAddress": "10.1.0.4", "publicIpAddress": "192.0.2.44"}]}}]}
+}`))
+	})
+}
+
+func fakeOCI(mux *http.ServeMux) {
+	authorized := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer Oracle" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			_, _ = w.Write([]byte(body))
+		}
+	}
+
+	mux.HandleFunc("/opc/v2/instance/", authorized(`{
+  "id": "ocid1.instance.oc1.iad.abc", "displayName": "bastion", "region": "iad", "canonicalRegionName": "us-ashburn-1",
+  "availabilityDomain": "Uocm:US-ASHBURN-AD-1", "compartmentId": "ocid1.compartment.oc1..xyz", "shape": "VM.Standard.E4.Flex"
+}`))
+	mux.HandleFunc("/opc/v2/vnics/", authorized(`[{"privateIp": "10.0.0.5"}]`))
+}
+
+func fakeDigitalOcean(mux *http.ServeMux) {
+	mux.HandleFunc("/metadata/v1.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+  "droplet_id": 2756294, "hostname": "bastion", "region": "nyc3",
+  "interfaces": {"public": [{"ipv4": {"ip_address": "104.131.20.105"}}], "private": [{"ipv4": {"ip_address": "10.132.255.113"}}]}
+}`))
+	})
+}
+
+func client(t *testing.T, fakes ...func(*http.ServeMux)) *Client {
+	mux := http.NewServeMux()
+
+	for _, f := range fakes {
+		f(mux)
+	}
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	endpoints := map[string]string{}
+
+	for _, p := range Providers {
+		endpoints[p] = server.URL + "/"
+	}
+
+	return &Client{HTTP: server.Client(), Endpoints: endpoints}
+}
+
+func TestDetect(t *testing.T) {
+	for name, tc := range map[string]struct {
+		fake     func(*http.ServeMux)
+		expected Instance
+	}{
+		"aws": {fakeAWS, Instance{
+			Provider:     AWS,
+			InstanceID:   "i-0123456789abcdef0",
+			Region:       "eu-west-2",
+			Zone:         "eu-west-2a",
+			Account:      "123456789012",
+			InstanceType: "t3.micro",
+			PrivateIP:    "10.0.1.23",
+			PublicIP:     "203.0.113.10",
+		}},
+		"gcp": {fakeGCP, Instance{
+			Provider:     GCP,
+			InstanceID:   "4520031799277581759",
+			Name:         "bastion",
+			Region:       "us-central1",
+			Zone:         "us-central1-a",
+			Account:      "my-project",
+			InstanceType: "e2-medium",
+			PrivateIP:    "10.128.0.2",
+			PublicIP:     "198.51.100.7",
+		}},
+		"azure": {fakeAzure, Instance{
+			Provider:     Azure,
+			InstanceID:   "02aab8a4-74ef-476e-8182-f6d2ba4166a6",
+			Name:         "bastion",
+			Region:       "westeurope",
+			Zone:         "1",
+			Account:      "8d10da13-8125-4ba9-a717-bf7490507b3d",
+			InstanceType: "Standard_B2s",
+			PrivateIP:    "10.1.0.4",
+			PublicIP:     "192.0.2.44",
+		}},
+		"oci": {fakeOCI, Instance{
+			Provider:     OCI,
+			InstanceID:   "ocid1.instance.oc1.iad.abc",
+			Name:         "bastion",
+			Region:       "us-ashburn-1",
+			Zone:         "Uocm:US-ASHBURN-AD-1",
+			Account:      "ocid1.compartment.oc1..xyz",
+			InstanceType: "VM.Standard.E4.Flex",
+			PrivateIP:    "10.0.0.5",
+		}},
+		"digitalocean": {fakeDigitalOcean, Instance{
+			Provider:   DigitalOcean,
+			InstanceID: "2756294",
+			Name:       "bastion",
+			Region:     "nyc3",
+			PrivateIP:  "10.132.255.113",
+			PublicIP:   "104.131.20.105",
+		}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			i, err := client(t, tc.fake).Detect(context.Background(), Providers)
+			require.NoError(t, err)
+			require.Equal(t, &tc.expected, i)
+		})
+	}
+}
+
+func TestDetectOrder(t *testing.T) {
+	c := client(t, fakeAWS, fakeDigitalOcean)
+
+	i, err := c.Detect(context.Background(), Providers)
+	require.NoError(t, err)
+	require.Equal(t, AWS, i.Provider)
+
+	i, err = c.Detect(context.Background(), []string{DigitalOcean})
+	require.NoError(t, err)
+	require.Equal(t, DigitalOcean, i.Provider)
+}
+
+func TestDetectNotFound(t *testing.T) {
+	_, err := client(t).Detect(context.Background(), Providers)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = client(t).Detect(context.Background(), []string{"ibm"})
+	require.ErrorContains(t, err, "unsupported provider")
+}
+
+func TestDetectTimeout(t *testing.T) {
+	block := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(block) })
+
+	c := &Client{HTTP: server.Client(), Endpoints: map[string]string{AWS: server.URL}}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := c.Detect(ctx, []string{AWS})
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/latest/api/token", http.RedirectHandler("/elsewhere", http.StatusTemporaryRedirect))
+	mux.HandleFunc("/elsewhere", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("token"))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	c := NewHTTPClient()
+	require.Nil(t, c.Transport.(*http.Transport).Proxy)
+
+	// Redirects are returned as errors, not followed
+	_, err := (&Client{}).request(context.Background(), http.MethodPut, server.URL+"/latest/api/token", nil)
+	require.ErrorContains(t, err, "307")
+}
@@ -0,0 +1,25 @@
+//go:build linux
+
+package cpuinfo
+
+import "golang.org/x/sys/unix"
+
+// Affinity returns the CPUs the process may run on, from sched_getaffinity(2).
+// This is reduced by e.g. taskset, cpusets of containers and systemd CPUAffinity.
+func Affinity() ([]int, error) {
+	var set unix.CPUSet
+
+	if err := unix.SchedGetaffinity(0, &set); err != nil {
+		return nil, err
+	}
+
+	cpus := make([]int, 0, set.Count())
+
+	for cpu := 0; len(cpus) < set.Count(); cpu++ {
+		if set.IsSet(cpu) {
+			cpus = append(cpus, cpu)
+		}
+	}
+
+	return cpus, nil
+}
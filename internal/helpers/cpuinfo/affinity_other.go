@@ -0,0 +1,7 @@
+//go:build !linux
+
+package cpuinfo
+
+func Affinity() ([]int, error) {
+	return nil, ErrNotSupported
+}
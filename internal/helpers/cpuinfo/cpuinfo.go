@@ -0,0 +1,293 @@
+package cpuinfo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrNotSupported is returned on systems without /proc and /sys.
+var ErrNotSupported = errors.New("CPU details are not supported on this operating system")
+
+// DefaultProcRoot and DefaultSysRoot are where procfs and sysfs are mounted.
+const (
+	DefaultProcRoot = "/proc"
+	DefaultSysRoot  = "/sys"
+)
+
+// Info describes the CPUs of the machine.
+type Info struct {
+	// Logical is the number of online logical CPUs (hardware threads)
+	Logical int
+
+	// Physical is the number of physical cores, or 0 if unknown
+	Physical int
+
+	// Sockets is the number of CPU packages, or 0 if unknown
+	Sockets int
+
+	Vendor    string
+	ModelName string
+
+	// BaseFrequencyMHz and MaxFrequencyMHz are 0 if unknown
+	BaseFrequencyMHz float64
+	MaxFrequencyMHz  float64
+
+	// Flags are the features of the CPU, sorted, e.g. "avx2", "aes", "sve"
+	Flags []string
+}
+
+// Processor holds the fields of one processor in /proc/cpuinfo.
+type Processor map[string]string
+
+// armImplementers maps the "CPU implementer" of ARM processors to a vendor.
+var armImplementers = map[string]string{
+	"0x41": "ARM",
+	"0x42": "Broadcom",
+	"0x43": "Cavium",
+	"0x46": "Fujitsu",
+	"0x48": "HiSilicon",
+	"0x4e": "NVIDIA",
+	"0x50": "APM",
+	"0x51": "Qualcomm",
+	"0x61": "Apple",
+	"0x6d": "Microsoft",
+	"0xc0": "Ampere",
+}
+
+// Frequency in a model name such as "Intel(R) Core(TM) i7-8550U CPU @ 1.80GHz"
+var modelFrequencyRegex = regexp.MustCompile(`@\s*([0-9.]+)\s*GHz`)
+
+// Read gets the CPU details from procfs and sysfs mounted at the given roots.
+func Read(procRoot, sysRoot string) (*Info, error) {
+	f, err := os.Open(filepath.Join(procRoot, "cpuinfo"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	processors, err := ParseCPUInfo(f)
+
+	if err != nil {
+		return nil, err
+	}
+
+	info := fromCPUInfo(processors)
+	cpuDir := filepath.Join(sysRoot, "devices", "system", "cpu")
+
+	if online, err := readCPUList(filepath.Join(cpuDir, "online")); err == nil && len(online) > 0 {
+		info.Logical = len(online)
+
+		if physical, sockets := topology(cpuDir, online); physical > 0 {
+			info.Physical, info.Sockets = physical, sockets
+		}
+	}
+
+	// base_frequency is only provided by intel_pstate; otherwise use the model name
+	if khz, err := readInt(filepath.Join(cpuDir, "cpu0", "cpufreq", "base_frequency")); err == nil && khz > 0 {
+		info.BaseFrequencyMHz = float64(khz) / 1000
+	}
+
+	if khz, err := readInt(filepath.Join(cpuDir, "cpu0", "cpufreq", "cpuinfo_max_freq")); err == nil && khz > 0 {
+		info.MaxFrequencyMHz = float64(khz) / 1000
+	}
+
+	return info, nil
+}
+
+// ParseCPUInfo splits the content of /proc/cpuinfo into processors. Fields
+// that precede or follow the processors, as on ARM, are included in each processor.
+func ParseCPUInfo(r io.Reader) ([]Processor, error) {
+	var processors []Processor
+	common := Processor{}
+	current := Processor{}
+
+	flush := func() {
+		if _, ok := current["processor"]; ok {
+			processors = append(processors, current)
+		} else {
+			for k, v := range current {
+				common[k] = v
+			}
+		}
+
+		current = Processor{}
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+
+		if !ok {
+			continue
+		}
+
+		current[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	flush()
+
+	for _, p := range processors {
+		for k, v := range common {
+			if _, ok := p[k]; !ok {
+				p[k] = v
+			}
+		}
+	}
+
+	return processors, nil
+}
+
+// ParseCPUList parses a list of CPUs in the kernel's format, e.g. "0-3,8,10-11".
+func ParseCPUList(s string) ([]int, error) {
+	var cpus []int
+
+	for _, part := range strings.Split(strings.TrimSpace(s), ",") {
+		if part == "" {
+			continue
+		}
+
+		first, last, isRange := strings.Cut(part, "-")
+		from, err := strconv.Atoi(first)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid CPU list %q", s)
+		}
+
+		to := from
+
+		if isRange {
+			if to, err = strconv.Atoi(last); err != nil || to < from {
+				return nil, fmt.Errorf("invalid CPU list %q", s)
+			}
+		}
+
+		for cpu := from; cpu <= to; cpu++ {
+			cpus = append(cpus, cpu)
+		}
+	}
+
+	return cpus, nil
+}
+
+// fromCPUInfo gets what can be found from /proc/cpuinfo alone.
+func fromCPUInfo(processors []Processor) *Info {
+	info := &Info{
+		Logical: len(processors),
+	}
+
+	if len(processors) == 0 {
+		return info
+	}
+
+	p := processors[0]
+
+	// x86 and ARM respectively
+	info.Vendor = firstOf(p, "vendor_id", "vendor")
+
+	if info.Vendor == "" {
+		info.Vendor = armImplementers[strings.ToLower(p["CPU implementer"])]
+	}
+
+	info.ModelName = firstOf(p, "model name", "cpu model", "Model", "Hardware", "cpu")
+
+	if m := modelFrequencyRegex.FindStringSubmatch(info.ModelName); m != nil {
+		if ghz, err := strconv.ParseFloat(m[1], 64); err == nil {
+			info.BaseFrequencyMHz = ghz * 1000
+		}
+	}
+
+	// x86 and ARM respectively
+	info.Flags = strings.Fields(firstOf(p, "flags", "Features"))
+	sort.Strings(info.Flags)
+
+	// Physical IDs and core IDs are not present on all architectures
+	cores := map[string]bool{}
+	sockets := map[string]bool{}
+
+	for _, p := range processors {
+		if pkg, ok := p["physical id"]; ok {
+			sockets[pkg] = true
+			cores[pkg+"/"+p["core id"]] = true
+		}
+	}
+
+	info.Physical = len(cores)
+	info.Sockets = len(sockets)
+
+	return info
+}
+
+// topology counts the cores and packages of the given CPUs from sysfs.
+func topology(cpuDir string, cpus []int) (physical, sockets int) {
+	cores := map[string]bool{}
+	packages := map[string]bool{}
+
+	for _, cpu := range cpus {
+		dir := filepath.Join(cpuDir, fmt.Sprintf("cpu%d", cpu), "topology")
+		pkg, err1 := os.ReadFile(filepath.Join(dir, "physical_package_id"))
+		core, err2 := os.ReadFile(filepath.Join(dir, "core_id"))
+
+		if err1 != nil || err2 != nil {
+			return 0, 0
+		}
+
+		p := strings.TrimSpace(string(pkg))
+		packages[p] = true
+		cores[p+"/"+strings.TrimSpace(string(core))] = true
+	}
+
+	return len(cores), len(packages)
+}
+
+func readCPUList(path string) ([]int, error) {
+	b, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseCPUList(string(b))
+}
+
+func readInt(path string) (int64, error) {
+	b, err := os.ReadFile(path)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+}
+
+func firstOf(p Processor, keys ...string) string {
+	for _, k := range keys {
+		if v := p[k]; v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,154 @@
+package cpuinfo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// x86Processor is an entry of /proc/cpuinfo on x86
+const x86Processor = `processor	: %d
+vendor_id	: GenuineIntel
+cpu family	: 6
+model name	: Intel(R) Xeon(R) Gold 6148 CPU @ 2.40GHz
+cpu MHz		: 1000.000
+physical id	: %d
+siblings	: 4
+core id		: %d
+cpu cores	: 2
+flags		: fpu sse2 avx2 aes
+
+`
+
+// arm is /proc/cpuinfo of a Raspberry Pi 4
+const arm = `processor	: 0
+BogoMIPS	: 108.00
+Features	: fp asimd evtstrm crc32 cpuid
+CPU implementer	: 0x41
+CPU architecture: 8
+CPU part	: 0xd08
+
+processor	: 1
+BogoMIPS	: 108.00
+Features	: fp asimd evtstrm crc32 cpuid
+CPU implementer	: 0x41
+CPU architecture: 8
+CPU part	: 0xd08
+
+Hardware	: BCM2835
+Model		: Raspberry Pi 4 Model B Rev 1.4
+`
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+// x86Tree creates procfs and sysfs for 2 sockets of 2 cores with hyperthreading
+func x86Tree(t *testing.T, withSys bool) (string, string) {
+	root := t.TempDir()
+	proc := filepath.Join(root, "proc")
+	sys := filepath.Join(root, "sys")
+
+	var b strings.Builder
+
+	for cpu := 0; cpu < 8; cpu++ {
+		pkg, core := cpu/4, cpu%2
+		fmt.Fprintf(&b, x86Processor, cpu, pkg, core)
+
+		if withSys {
+			dir := filepath.Join(sys, "devices", "system", "cpu", fmt.Sprintf("cpu%d", cpu), "topology")
+			writeFile(t, filepath.Join(dir, "physical_package_id"), fmt.Sprintf("%d\n", pkg))
+			writeFile(t, filepath.Join(dir, "core_id"), fmt.Sprintf("%d\n", core))
+		}
+	}
+
+	writeFile(t, filepath.Join(proc, "cpuinfo"), b.String())
+
+	if withSys {
+		cpuDir := filepath.Join(sys, "devices", "system", "cpu")
+		writeFile(t, filepath.Join(cpuDir, "online"), "0-7\n")
+		writeFile(t, filepath.Join(cpuDir, "cpu0", "cpufreq", "base_frequency"), "2400000\n")
+		writeFile(t, filepath.Join(cpuDir, "cpu0", "cpufreq", "cpuinfo_max_freq"), "3700000\n")
+	}
+
+	return proc, sys
+}
+
+func TestReadX86(t *testing.T) {
+	proc, sys := x86Tree(t, true)
+
+	info, err := Read(proc, sys)
+	require.NoError(t, err)
+
+	require.Equal(t, &Info{
+		Logical:          8,
+		Physical:         4,
+		Sockets:          2,
+		Vendor:           "GenuineIntel",
+		ModelName:        "Intel(R) Xeon(R) Gold 6148 CPU @ 2.40GHz",
+		BaseFrequencyMHz: 2400,
+		MaxFrequencyMHz:  3700,
+		Flags:            []string{"aes", "avx2", "fpu", "sse2"},
+	}, info)
+}
+
+func TestReadWithoutSys(t *testing.T) {
+	proc, _ := x86Tree(t, false)
+
+	info, err := Read(proc, filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+
+	require.Equal(t, 8, info.Logical)
+	require.Equal(t, 4, info.Physical)
+	require.Equal(t, 2, info.Sockets)
+	require.Equal(t, float64(2400), info.BaseFrequencyMHz, "from model name")
+	require.Zero(t, info.MaxFrequencyMHz)
+}
+
+func TestReadARM(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "cpuinfo"), arm)
+
+	info, err := Read(root, root)
+	require.NoError(t, err)
+
+	require.Equal(t, 2, info.Logical)
+	require.Zero(t, info.Physical)
+	require.Equal(t, "ARM", info.Vendor)
+	require.Equal(t, "Raspberry Pi 4 Model B Rev 1.4", info.ModelName)
+	require.Equal(t, []string{"asimd", "cpuid", "crc32", "evtstrm", "fp"}, info.Flags)
+}
+
+func TestParseCPUList(t *testing.T) {
+	cpus, err := ParseCPUList("0-3,8,10-11\n")
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 1, 2, 3, 8, 10, 11}, cpus)
+
+	cpus, err = ParseCPUList("")
+	require.NoError(t, err)
+	require.Empty(t, cpus)
+
+	for _, s := range []string{"a", "3-1", "1-x"} {
+		_, err = ParseCPUList(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestAffinity(t *testing.T) {
+	cpus, err := Affinity()
+
+	if runtime.GOOS != "linux" {
+		require.ErrorIs(t, err, ErrNotSupported)
+		return
+	}
+
+	require.NoError(t, err)
+	require.Len(t, cpus, runtime.NumCPU())
+}
@@ -0,0 +1,345 @@
+package dhcplease
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	SourceDhclient       = "dhclient"
+	SourceNetworkd       = "systemd-networkd"
+	SourceNetworkManager = "networkmanager"
+	dhclientTimeLayout   = "2006/01/02 15:04:05"
+)
+
+type Lease struct {
+	Interface string
+	Source    string
+	Path      string
+
+	Address    string
+	SubnetMask string
+	Server     string
+	Domain     string
+	Routers    []string
+	DNS        []string
+	NTP        []string
+
+	// Times are zero if not known
+	Start  time.Time
+	Renew  time.Time
+	Expiry time.Time
+}
+
+// Paths are the locations searched for lease files.
+type Paths struct {
+	// Directories containing dhclient lease files,
+	// including those written by NetworkManager's dhclient backend
+	DhclientDirs []string
+
+	// Directory of systemd-networkd leases, named by interface index
+	NetworkdLeases string
+
+	// Directory of leases obtained by NetworkManager's internal DHCP client
+	NetworkManager string
+}
+
+var DefaultPaths = Paths{
+	DhclientDirs:   []string{"/var/lib/dhcp", "/var/lib/dhclient", "/var/lib/NetworkManager"},
+	NetworkdLeases: "/run/systemd/netif/leases",
+	NetworkManager: "/var/lib/NetworkManager",
+}
+
+// Replaced in tests, as systemd-networkd names lease files by interface index.
+var interfaceIndex = func(name string) (int, error) {
+	ifi, err := net.InterfaceByName(name)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return ifi.Index, nil
+}
+
+// Find returns the lease for iface from whichever DHCP client holds the most recent one,
+// or nil if no lease is found. Lease files that cannot be read or parsed are skipped,
+// so that one bad file does not hide the leases in the others. Their errors are joined
+// in the error returned, with the lease found in the rest.
+func Find(paths Paths, iface string) (*Lease, error) {
+	candidates := make([]*Lease, 0, 4)
+	errs := make([]error, 0)
+
+	for _, dir := range paths.DhclientDirs {
+		files, err := filepath.Glob(filepath.Join(dir, "dhclient*.lease*"))
+
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, f := range files {
+			leases, err := readFile(f, ParseDhclient)
+
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			// dhclient appends to the file, so the last lease is the most recent
+			for i := len(leases) - 1; i >= 0; i-- {
+				if leases[i].Interface == iface {
+					candidates = append(candidates, leases[i])
+					break
+				}
+			}
+		}
+	}
+
+	if index, err := interfaceIndex(iface); err == nil {
+		lease, err := readKeyValueLease(filepath.Join(paths.NetworkdLeases, strconv.Itoa(index)), SourceNetworkd, iface)
+
+		if err != nil {
+			errs = append(errs, err)
+		} else if lease != nil {
+			candidates = append(candidates, lease)
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join(paths.NetworkManager, "internal-*-"+iface+".lease"))
+
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	for _, f := range files {
+		lease, err := readKeyValueLease(f, SourceNetworkManager, iface)
+
+		if err != nil {
+			errs = append(errs, err)
+		} else if lease != nil {
+			candidates = append(candidates, lease)
+		}
+	}
+
+	var latest *Lease
+
+	for _, l := range candidates {
+		if latest == nil || l.Expiry.After(latest.Expiry) {
+			latest = l
+		}
+	}
+
+	return latest, errors.Join(errs...)
+}
+
+func readFile(path string, parse func(io.Reader) ([]*Lease, error)) ([]*Lease, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	leases, err := parse(f)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	for _, l := range leases {
+		l.Path = path
+	}
+
+	return leases, nil
+}
+
+// readKeyValueLease reads a lease in the format written by systemd's DHCP client, which is
+// used by both systemd-networkd and NetworkManager. Returns nil if the file does not exist.
+func readKeyValueLease(path, source, iface string) (*Lease, error) {
+	fi, err := os.Stat(path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	leases, err := readFile(path, func(r io.Reader) ([]*Lease, error) {
+		l, err := ParseKeyValue(r, fi.ModTime())
+		return []*Lease{l}, err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	leases[0].Source = source
+	leases[0].Interface = iface
+	return leases[0], nil
+}
+
+// ParseKeyValue parses a lease in the KEY=value format written by systemd's DHCP client.
+// These do not record when the lease was obtained, so the time the file was written
+// is taken as the start of the lease.
+func ParseKeyValue(r io.Reader, written time.Time) (*Lease, error) {
+	lease := &Lease{}
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		key, value, ok := strings.Cut(line, "=")
+
+		if !ok || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		switch key {
+		case "ADDRESS":
+			lease.Address = value
+		case "NETMASK":
+			lease.SubnetMask = value
+		case "ROUTER":
+			lease.Routers = strings.Fields(value)
+		case "SERVER_ADDRESS":
+			lease.Server = value
+		case "DOMAINNAME":
+			lease.Domain = value
+		case "DNS":
+			lease.DNS = strings.Fields(value)
+		case "NTP":
+			lease.NTP = strings.Fields(value)
+		case "T1":
+			if seconds, err := strconv.Atoi(value); err == nil {
+				lease.Renew = written.Add(time.Duration(seconds) * time.Second)
+			}
+		case "LIFETIME":
+			if seconds, err := strconv.Atoi(value); err == nil {
+				lease.Expiry = written.Add(time.Duration(seconds) * time.Second)
+			}
+		}
+	}
+
+	lease.Start = written
+	return lease, scanner.Err()
+}
+
+// ParseDhclient parses an ISC dhclient lease file, returning the leases in the order they appear.
+func ParseDhclient(r io.Reader) ([]*Lease, error) {
+	result := make([]*Lease, 0, 4)
+	scanner := bufio.NewScanner(r)
+
+	var current *Lease
+	var leaseTime time.Duration
+
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		line = strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(line, "lease") && strings.HasSuffix(line, "{"):
+			current = &Lease{Source: SourceDhclient}
+			leaseTime = 0
+			continue
+		case line == "}" && current != nil:
+			if !current.Expiry.IsZero() && leaseTime > 0 {
+				current.Start = current.Expiry.Add(-leaseTime)
+			}
+
+			result = append(result, current)
+			current = nil
+			continue
+		case current == nil:
+			continue
+		}
+
+		keyword, rest, _ := strings.Cut(strings.TrimSuffix(line, ";"), " ")
+
+		switch keyword {
+		case "interface":
+			current.Interface = unquote(rest)
+		case "fixed-address":
+			current.Address = rest
+		case "renew":
+			current.Renew = parseDhclientTime(rest)
+		case "expire":
+			current.Expiry = parseDhclientTime(rest)
+		case "option":
+			name, value, _ := strings.Cut(rest, " ")
+
+			switch name {
+			case "subnet-mask":
+				current.SubnetMask = value
+			case "routers":
+				current.Routers = splitList(value)
+			case "dhcp-server-identifier":
+				current.Server = value
+			case "domain-name":
+				current.Domain = unquote(value)
+			case "domain-name-servers":
+				current.DNS = splitList(value)
+			case "ntp-servers":
+				current.NTP = splitList(value)
+			case "dhcp-lease-time":
+				if seconds, err := strconv.Atoi(value); err == nil {
+					leaseTime = time.Duration(seconds) * time.Second
+				}
+			}
+		}
+	}
+
+	return result, scanner.Err()
+}
+
+// parseDhclientTime parses "<weekday> <yyyy/mm/dd> <hh:mm:ss>" in UTC,
+// or "epoch <seconds>" as written when db-time-format is local.
+// Returns the zero time for "never" or anything unrecognised.
+func parseDhclientTime(s string) time.Time {
+	fields := strings.Fields(s)
+
+	if len(fields) >= 2 && fields[0] == "epoch" {
+		if seconds, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+			return time.Unix(seconds, 0).UTC()
+		}
+
+		return time.Time{}
+	}
+
+	if len(fields) < 3 {
+		return time.Time{}
+	}
+
+	t, err := time.Parse(dhclientTimeLayout, fields[1]+" "+fields[2])
+
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t
+}
+
+func splitList(s string) []string {
+	result := make([]string, 0, 2)
+
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+func unquote(s string) string {
+	return strings.Trim(s, `"`)
+}
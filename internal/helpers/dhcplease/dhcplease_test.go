@@ -0,0 +1,173 @@
+package dhcplease
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const dhclientLeases = `lease {
+  interface "eth0";
+  fixed-address 192.168.1.23;
+  option subnet-mask 255.255.255.0;
+  option routers 192.168.1.1;
+  option dhcp-lease-time 3600;
+  option domain-name "old.lan";
+  renew 1 2023/10/16 09:30:00;
+  expire 1 2023/10/16 10:00:00;
+}
+lease {
+  interface "eth0";
+  fixed-address 192.168.1.23;
+  option subnet-mask 255.255.255.0;
+  option routers 192.168.1.1;
+  option dhcp-lease-time 86400;
+  option dhcp-server-identifier 192.168.1.1;
+  option domain-name-servers 192.168.1.1,8.8.8.8;
+  option ntp-servers 192.168.1.2;
+  option domain-name "home.lan";
+  renew 2 2023/10/17 12:00:00;
+  rebind 3 2023/10/18 00:00:00;
+  expire 3 2023/10/18 03:00:00;
+}
+lease {
+  interface "wlan0";
+  fixed-address 10.0.0.5;
+  expire epoch 1697600000; # Wed Oct 18 03:33:20 2023
+}
+`
+
+const networkdLease = `# This is private data. Do not parse.
+ADDRESS=192.168.49.10
+NETMASK=255.255.255.0
+ROUTER=192.168.49.1
+SERVER_ADDRESS=192.168.49.1
+T1=1800
+T2=3150
+LIFETIME=3600
+DNS=192.168.49.1 1.1.1.1
+NTP=192.168.49.1
+DOMAINNAME=lab.example
+`
+
+func TestParseDhclient(t *testing.T) {
+	leases, err := ParseDhclient(strings.NewReader(dhclientLeases))
+
+	require.NoError(t, err)
+	require.Len(t, leases, 3)
+
+	l := leases[1]
+	require.Equal(t, "eth0", l.Interface)
+	require.Equal(t, SourceDhclient, l.Source)
+	require.Equal(t, "192.168.1.23", l.Address)
+	require.Equal(t, "255.255.255.0", l.SubnetMask)
+	require.Equal(t, "192.168.1.1", l.Server)
+	require.Equal(t, "home.lan", l.Domain)
+	require.Equal(t, []string{"192.168.1.1"}, l.Routers)
+	require.Equal(t, []string{"192.168.1.1", "8.8.8.8"}, l.DNS)
+	require.Equal(t, []string{"192.168.1.2"}, l.NTP)
+	require.Equal(t, time.Date(2023, 10, 17, 12, 0, 0, 0, time.UTC), l.Renew)
+	require.Equal(t, time.Date(2023, 10, 18, 3, 0, 0, 0, time.UTC), l.Expiry)
+	require.Equal(t, time.Date(2023, 10, 17, 3, 0, 0, 0, time.UTC), l.Start)
+
+	require.Equal(t, time.Unix(1697600000, 0).UTC(), leases[2].Expiry)
+	require.True(t, leases[2].Start.IsZero())
+}
+
+func TestParseKeyValue(t *testing.T) {
+	written := time.Date(2023, 10, 17, 12, 0, 0, 0, time.UTC)
+	l, err := ParseKeyValue(strings.NewReader(networkdLease), written)
+
+	require.NoError(t, err)
+	require.Equal(t, "192.168.49.10", l.Address)
+	require.Equal(t, "192.168.49.1", l.Server)
+	require.Equal(t, "lab.example", l.Domain)
+	require.Equal(t, []string{"192.168.49.1", "1.1.1.1"}, l.DNS)
+	require.Equal(t, written, l.Start)
+	require.Equal(t, written.Add(30*time.Minute), l.Renew)
+	require.Equal(t, written.Add(time.Hour), l.Expiry)
+}
+
+func testPaths(t *testing.T) Paths {
+	dir := t.TempDir()
+	paths := Paths{
+		DhclientDirs:   []string{filepath.Join(dir, "dhcp")},
+		NetworkdLeases: filepath.Join(dir, "leases"),
+		NetworkManager: filepath.Join(dir, "nm"),
+	}
+
+	for _, d := range []string{paths.DhclientDirs[0], paths.NetworkdLeases, paths.NetworkManager} {
+		require.NoError(t, os.MkdirAll(d, 0o755))
+	}
+
+	saved := interfaceIndex
+	interfaceIndex = func(name string) (int, error) {
+		return map[string]int{"eth0": 2, "eth1": 3}[name], nil
+	}
+
+	t.Cleanup(func() {
+		interfaceIndex = saved
+	})
+
+	return paths
+}
+
+func TestFindDhclient(t *testing.T) {
+	paths := testPaths(t)
+	require.NoError(t, os.WriteFile(filepath.Join(paths.DhclientDirs[0], "dhclient.eth0.leases"), []byte(dhclientLeases), 0o644))
+
+	l, err := Find(paths, "eth0")
+
+	require.NoError(t, err)
+	require.Equal(t, "home.lan", l.Domain)
+	require.Equal(t, filepath.Join(paths.DhclientDirs[0], "dhclient.eth0.leases"), l.Path)
+
+	l, err = Find(paths, "eth1")
+
+	require.NoError(t, err)
+	require.Nil(t, l)
+}
+
+func TestFindPrefersMostRecent(t *testing.T) {
+	paths := testPaths(t)
+	require.NoError(t, os.WriteFile(filepath.Join(paths.DhclientDirs[0], "dhclient.eth0.leases"), []byte(dhclientLeases), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(paths.NetworkdLeases, "2"), []byte(networkdLease), 0o644))
+
+	l, err := Find(paths, "eth0")
+
+	require.NoError(t, err)
+	require.Equal(t, SourceNetworkd, l.Source)
+	require.Equal(t, "eth0", l.Interface)
+	require.Equal(t, "lab.example", l.Domain)
+}
+
+func TestFindNetworkManager(t *testing.T) {
+	paths := testPaths(t)
+	path := filepath.Join(paths.NetworkManager, "internal-4b2c3f8e-1d2a-4c3b-9e8f-0a1b2c3d4e5f-eth1.lease")
+	require.NoError(t, os.WriteFile(path, []byte(networkdLease), 0o644))
+
+	l, err := Find(paths, "eth1")
+
+	require.NoError(t, err)
+	require.Equal(t, SourceNetworkManager, l.Source)
+	require.Equal(t, path, l.Path)
+}
+
+func TestFindSkipsUnreadableFiles(t *testing.T) {
+	paths := testPaths(t)
+	require.NoError(t, os.WriteFile(filepath.Join(paths.DhclientDirs[0], "dhclient.eth0.leases"), []byte(dhclientLeases), 0o644))
+
+	// A directory with a lease file name cannot be read
+	bad := filepath.Join(paths.DhclientDirs[0], "dhclient.bad.leases")
+	require.NoError(t, os.Mkdir(bad, 0o755))
+
+	l, err := Find(paths, "eth0")
+
+	require.ErrorContains(t, err, bad)
+	require.NotNil(t, l)
+	require.Equal(t, "home.lan", l.Domain)
+}
@@ -0,0 +1,251 @@
+package dmi
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultSysRoot is where sysfs is mounted.
+const DefaultSysRoot = "/sys"
+
+// ErrNotSupported is returned when the kernel does not export DMI, e.g. on
+// ARM boards without SMBIOS, or in some containers and micro VMs.
+var ErrNotSupported = errors.New("DMI is not available on this machine")
+
+// Info holds the SMBIOS details of the machine. Fields are empty if not known.
+type Info struct {
+	SystemVendor   string
+	ProductName    string
+	ProductVersion string
+	ProductFamily  string
+	BoardVendor    string
+	BoardName      string
+	BoardVersion   string
+	BIOSVendor     string
+	BIOSVersion    string
+
+	// BIOSDate is YYYY-MM-DD where the firmware gives a valid date
+	BIOSDate string
+
+	ChassisVendor string
+
+	// ChassisType is the SMBIOS chassis type, or 0 if unknown
+	ChassisType int
+
+	// Serial and UUID are only read if requested, and usually need root
+	Serial string
+	UUID   string
+}
+
+// chassisTypes are the names of the SMBIOS chassis types, from section 7.4.1
+// of the SMBIOS specification.
+var chassisTypes = []string{
+	1:  "Other",
+	2:  "Unknown",
+	3:  "Desktop",
+	4:  "Low Profile Desktop",
+	5:  "Pizza Box",
+	6:  "Mini Tower",
+	7:  "Tower",
+	8:  "Portable",
+	9:  "Laptop",
+	10: "Notebook",
+	11: "Hand Held",
+	12: "Docking Station",
+	13: "All in One",
+	14: "Sub Notebook",
+	15: "Space-saving",
+	16: "Lunch Box",
+	17: "Main Server Chassis",
+	18: "Expansion Chassis",
+	19: "SubChassis",
+	20: "Bus Expansion Chassis",
+	21: "Peripheral Chassis",
+	22: "RAID Chassis",
+	23: "Rack Mount Chassis",
+	24: "Sealed-case PC",
+	25: "Multi-system Chassis",
+	26: "Compact PCI",
+	27: "Advanced TCA",
+	28: "Blade",
+	29: "Blade Enclosure",
+	30: "Tablet",
+	31: "Convertible",
+	32: "Detachable",
+	33: "IoT Gateway",
+	34: "Embedded PC",
+	35: "Mini PC",
+	36: "Stick PC",
+}
+
+// Chassis classes, as used by systemd-hostnamed.
+const (
+	ClassDesktop     = "desktop"
+	ClassLaptop      = "laptop"
+	ClassConvertible = "convertible"
+	ClassServer      = "server"
+	ClassTablet      = "tablet"
+	ClassHandset     = "handset"
+	ClassEmbedded    = "embedded"
+)
+
+// chassisClasses maps chassis types to classes in the same way as systemd-hostnamed.
+var chassisClasses = map[int]string{
+	3:  ClassDesktop,
+	4:  ClassDesktop,
+	6:  ClassDesktop,
+	7:  ClassDesktop,
+	13: ClassDesktop,
+	35: ClassDesktop,
+	36: ClassDesktop,
+	8:  ClassLaptop,
+	9:  ClassLaptop,
+	10: ClassLaptop,
+	14: ClassLaptop,
+	11: ClassHandset,
+	17: ClassServer,
+	23: ClassServer,
+	28: ClassServer,
+	29: ClassServer,
+	30: ClassTablet,
+	31: ClassConvertible,
+	32: ClassConvertible,
+	33: ClassEmbedded,
+	34: ClassEmbedded,
+}
+
+// placeholders are values firmware vendors leave in fields they do not fill in.
+var placeholders = map[string]bool{
+	"":                        true,
+	"to be filled by o.e.m.":  true,
+	"to be filled by oem":     true,
+	"default string":          true,
+	"not specified":           true,
+	"not applicable":          true,
+	"not available":           true,
+	"not defined":             true,
+	"none":                    true,
+	"o.e.m.":                  true,
+	"oem":                     true,
+	"system manufacturer":     true,
+	"system product name":     true,
+	"system version":          true,
+	"system serial number":    true,
+	"base board manufacturer": true,
+	"0123456789":              true,
+	"123456789":               true,
+	"00000000":                true,
+	"x.x":                     true,
+
+	// UUIDs of boards that do not set one
+	"00000000-0000-0000-0000-000000000000": true,
+	"ffffffff-ffff-ffff-ffff-ffffffffffff": true,
+	"03000200-0400-0500-0006-000700080009": true,
+}
+
+// ChassisTypeName gets the SMBIOS name of a chassis type, e.g. "Notebook", or "" if not known.
+func ChassisTypeName(t int) string {
+	if t <= 0 || t >= len(chassisTypes) {
+		return ""
+	}
+
+	return chassisTypes[t]
+}
+
+// ChassisClass gets the kind of machine of a chassis type, one of the Class
+// constants, or "" for types such as "Other" that do not say.
+func ChassisClass(t int) string {
+	return chassisClasses[t]
+}
+
+// Read gets the DMI details exported by the kernel in sysfs mounted at sysRoot.
+// If identifiers is true, the serial number and UUID are also read, where permissions allow.
+func Read(sysRoot string, identifiers bool) (*Info, error) {
+	dir := filepath.Join(sysRoot, "class", "dmi", "id")
+
+	if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotSupported
+	} else if err != nil {
+		return nil, err
+	}
+
+	read := func(name string) string {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+
+		if err != nil {
+			return ""
+		}
+
+		return clean(string(b))
+	}
+
+	info := &Info{
+		SystemVendor:   read("sys_vendor"),
+		ProductName:    read("product_name"),
+		ProductVersion: read("product_version"),
+		ProductFamily:  read("product_family"),
+		BoardVendor:    read("board_vendor"),
+		BoardName:      read("board_name"),
+		BoardVersion:   read("board_version"),
+		BIOSVendor:     read("bios_vendor"),
+		BIOSVersion:    read("bios_version"),
+		BIOSDate:       parseDate(read("bios_date")),
+		ChassisVendor:  read("chassis_vendor"),
+	}
+
+	if t, err := strconv.Atoi(read("chassis_type")); err == nil && t > 0 {
+		info.ChassisType = t
+	}
+
+	if identifiers {
+		info.Serial = read("product_serial")
+		info.UUID = strings.ToLower(read("product_uuid"))
+	}
+
+	return info, nil
+}
+
+// Hash returns the hex HMAC-SHA256 of an identifier keyed by key, or "" for "", so that
+// machines can be told apart without disclosing their serial numbers. Unlike a plain
+// hash, the result cannot be reversed by hashing likely serial numbers without the key,
+// nor matched against hashes made with other keys.
+func Hash(key []byte, id string) string {
+	if id == "" {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// clean trims a value and drops placeholders.
+func clean(s string) string {
+	s = strings.TrimSpace(s)
+
+	if placeholders[strings.ToLower(s)] {
+		return ""
+	}
+
+	return s
+}
+
+// parseDate converts the MM/DD/YYYY dates of SMBIOS to YYYY-MM-DD. Dates that
+// cannot be parsed are returned as they are.
+func parseDate(s string) string {
+	for _, layout := range []string{"01/02/2006", "01/02/06"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format("2006-01-02")
+		}
+	}
+
+	return s
+}
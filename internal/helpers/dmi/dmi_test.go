@@ -0,0 +1,143 @@
+package dmi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// laptop is /sys/class/dmi/id of a ThinkPad, where product_serial and product_uuid are only readable by root
+var laptop = map[string]string{
+	"sys_vendor":      "LENOVO\n",
+	"product_name":    "20KH006MUK\n",
+	"product_version": "ThinkPad X1 Carbon 6th\n",
+	"product_family":  "ThinkPad X1 Carbon 6th\n",
+	"board_vendor":    "LENOVO\n",
+	"board_name":      "20KH006MUK\n",
+	"board_version":   "Not Defined\n",
+	"bios_vendor":     "LENOVO\n",
+	"bios_version":    "N23ET59W (1.34 )\n",
+	"bios_date":       "11/08/2018\n",
+	"chassis_vendor":  "LENOVO\n",
+	"chassis_type":    "10\n",
+	"product_serial":  "PF1ABCDE\n",
+	"product_uuid":    "4C4C4544-0036-3010-8052-B4C04F4C4E32\n",
+}
+
+// whitebox is a server board that leaves most fields at their defaults
+var whitebox = map[string]string{
+	"sys_vendor":      "To Be Filled By O.E.M.\n",
+	"product_name":    "To Be Filled By O.E.M.\n",
+	"product_version": "To Be Filled By O.E.M.\n",
+	"board_vendor":    "ASRockRack\n",
+	"board_name":      "X470D4U\n",
+	"bios_vendor":     "American Megatrends Inc.\n",
+	"bios_version":    "P3.30\n",
+	"bios_date":       "not a date\n",
+	"chassis_type":    "23\n",
+	"product_serial":  "To Be Filled By O.E.M.\n",
+	"product_uuid":    "03000200-0400-0500-0006-000700080009\n",
+}
+
+func sysTree(t *testing.T, files map[string]string) string {
+	root := t.TempDir()
+	dir := filepath.Join(root, "class", "dmi", "id")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+
+	return root
+}
+
+func TestReadLaptop(t *testing.T) {
+	root := sysTree(t, laptop)
+
+	info, err := Read(root, false)
+	require.NoError(t, err)
+
+	require.Equal(t, &Info{
+		SystemVendor:   "LENOVO",
+		ProductName:    "20KH006MUK",
+		ProductVersion: "ThinkPad X1 Carbon 6th",
+		ProductFamily:  "ThinkPad X1 Carbon 6th",
+		BoardVendor:    "LENOVO",
+		BoardName:      "20KH006MUK",
+		BIOSVendor:     "LENOVO",
+		BIOSVersion:    "N23ET59W (1.34 )",
+		BIOSDate:       "2018-11-08",
+		ChassisVendor:  "LENOVO",
+		ChassisType:    10,
+	}, info)
+
+	require.Equal(t, "Notebook", ChassisTypeName(info.ChassisType))
+	require.Equal(t, ClassLaptop, ChassisClass(info.ChassisType))
+
+	info, err = Read(root, true)
+	require.NoError(t, err)
+	require.Equal(t, "PF1ABCDE", info.Serial)
+	require.Equal(t, "4c4c4544-0036-3010-8052-b4c04f4c4e32", info.UUID)
+}
+
+func TestReadPlaceholders(t *testing.T) {
+	info, err := Read(sysTree(t, whitebox), true)
+	require.NoError(t, err)
+
+	require.Empty(t, info.SystemVendor)
+	require.Empty(t, info.ProductName)
+	require.Empty(t, info.ProductVersion)
+	require.Equal(t, "ASRockRack", info.BoardVendor)
+	require.Equal(t, "not a date", info.BIOSDate)
+	require.Equal(t, ClassServer, ChassisClass(info.ChassisType))
+	require.Empty(t, info.Serial)
+	require.Empty(t, info.UUID)
+}
+
+func TestReadUnreadableIdentifiers(t *testing.T) {
+	files := map[string]string{}
+
+	for k, v := range laptop {
+		files[k] = v
+	}
+
+	delete(files, "product_serial")
+	delete(files, "product_uuid")
+
+	info, err := Read(sysTree(t, files), true)
+	require.NoError(t, err)
+	require.Empty(t, info.Serial)
+	require.Empty(t, info.UUID)
+	require.Equal(t, "LENOVO", info.SystemVendor)
+}
+
+func TestReadNotSupported(t *testing.T) {
+	_, err := Read(t.TempDir(), false)
+	require.ErrorIs(t, err, ErrNotSupported)
+}
+
+func TestChassisType(t *testing.T) {
+	require.Equal(t, "Other", ChassisTypeName(1))
+	require.Equal(t, "Stick PC", ChassisTypeName(36))
+	require.Empty(t, ChassisTypeName(0))
+	require.Empty(t, ChassisTypeName(99))
+	require.Empty(t, ChassisClass(1))
+	require.Equal(t, ClassDesktop, ChassisClass(3))
+	require.Equal(t, ClassConvertible, ChassisClass(31))
+}
+
+func TestHash(t *testing.T) {
+	key := []byte("key")
+
+	require.Empty(t, Hash(key, ""))
+
+	// HMAC-SHA256 test vector from RFC 4231 section 4.3
+	require.Equal(t, "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843",
+		Hash([]byte("Jefe"), "what do ya want for nothing?"))
+
+	// Not the plain SHA-256, and different for each key
+	require.NotEqual(t, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", Hash(key, "test"))
+	require.NotEqual(t, Hash(key, "test"), Hash([]byte("other"), "test"))
+}
@@ -0,0 +1,208 @@
+package dnsconfig
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Resolver defaults as documented in resolv.conf(5).
+const (
+	DefaultNdots    = 1
+	DefaultTimeout  = 5
+	DefaultAttempts = 2
+)
+
+type Paths struct {
+	ResolvConf        string
+	SystemdResolvConf string
+	Nsswitch          string
+}
+
+// DefaultPaths are the locations of the resolver configuration files on Linux and other POSIX systems.
+var DefaultPaths = Paths{
+	ResolvConf:        "/etc/resolv.conf",
+	SystemdResolvConf: "/run/systemd/resolve/resolv.conf",
+	Nsswitch:          "/etc/nsswitch.conf",
+}
+
+// Addresses on which systemd-resolved's stub resolver listens.
+var systemdStubAddresses = map[string]bool{
+	"127.0.0.53": true,
+	"127.0.0.54": true,
+}
+
+type ResolvConf struct {
+	Nameservers []string
+	Search      []string
+	Domain      string
+	Ndots       int
+	Timeout     int
+	Attempts    int
+	Rotate      bool
+	Options     []string
+}
+
+type Config struct {
+	ResolvConf
+	SystemdResolvedStub bool
+	UpstreamNameservers []string
+
+	// NsswitchHosts is nil if nsswitch.conf does not exist.
+	NsswitchHosts []string
+}
+
+// Read reads the resolver configuration from the given paths.
+// An error is only returned if the main resolv.conf cannot be read.
+func Read(paths Paths) (*Config, error) {
+	f, err := os.Open(paths.ResolvConf)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	rc, err := ParseResolvConf(f)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
+		ResolvConf: *rc,
+	}
+
+	for _, ns := range rc.Nameservers {
+		if systemdStubAddresses[ns] {
+			cfg.SystemdResolvedStub = true
+		}
+	}
+
+	if target, err := filepath.EvalSymlinks(paths.ResolvConf); err == nil && filepath.Base(target) == "stub-resolv.conf" {
+		cfg.SystemdResolvedStub = true
+	}
+
+	if cfg.SystemdResolvedStub {
+		if upstream, err := os.Open(paths.SystemdResolvConf); err == nil {
+			defer upstream.Close()
+
+			if urc, err := ParseResolvConf(upstream); err == nil {
+				cfg.UpstreamNameservers = urc.Nameservers
+			}
+		}
+	}
+
+	if ns, err := os.Open(paths.Nsswitch); err == nil {
+		defer ns.Close()
+
+		if cfg.NsswitchHosts, err = ParseNsswitchHosts(ns); err != nil {
+			return nil, err
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// ParseResolvConf parses the content of a resolv.conf file.
+func ParseResolvConf(r io.Reader) (*ResolvConf, error) {
+	rc := &ResolvConf{
+		Nameservers: make([]string, 0, 3),
+		Search:      make([]string, 0, 4),
+		Options:     make([]string, 0, 4),
+		Ndots:       DefaultNdots,
+		Timeout:     DefaultTimeout,
+		Attempts:    DefaultAttempts,
+	}
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		fields := strings.Fields(stripComment(scanner.Text(), "#;"))
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "nameserver":
+			if len(fields) > 1 {
+				rc.Nameservers = append(rc.Nameservers, fields[1])
+			}
+
+		case "domain":
+			// domain and search are mutually exclusive. The last one given wins.
+			if len(fields) > 1 {
+				rc.Domain = fields[1]
+				rc.Search = []string{fields[1]}
+			}
+
+		case "search":
+			rc.Domain = ""
+			rc.Search = append(rc.Search[:0], fields[1:]...)
+
+		case "options":
+			for _, opt := range fields[1:] {
+				rc.Options = append(rc.Options, opt)
+				parseOption(rc, opt)
+			}
+		}
+	}
+
+	return rc, scanner.Err()
+}
+
+func parseOption(rc *ResolvConf, opt string) {
+	name, value, _ := strings.Cut(opt, ":")
+
+	switch name {
+	case "ndots":
+		if n, err := strconv.Atoi(value); err == nil {
+			rc.Ndots = n
+		}
+
+	case "timeout":
+		if n, err := strconv.Atoi(value); err == nil {
+			rc.Timeout = n
+		}
+
+	case "attempts":
+		if n, err := strconv.Atoi(value); err == nil {
+			rc.Attempts = n
+		}
+
+	case "rotate":
+		rc.Rotate = true
+	}
+}
+
+// ParseNsswitchHosts returns the sources listed on the hosts: line of nsswitch.conf,
+// including any [STATUS=action] criteria, in the order given.
+func ParseNsswitchHosts(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(stripComment(scanner.Text(), "#"))
+		database, sources, ok := strings.Cut(line, ":")
+
+		if ok && strings.TrimSpace(database) == "hosts" {
+			return strings.Fields(sources), nil
+		}
+	}
+
+	return make([]string, 0), scanner.Err()
+}
+
+func stripComment(line, commentChars string) string {
+	if i := strings.IndexAny(line, commentChars); i >= 0 {
+		return line[:i]
+	}
+
+	return line
+}
@@ -0,0 +1,118 @@
+package dnsconfig
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const stubResolvConf = `# This is /run/systemd/resolve/stub-resolv.conf managed by man:systemd-resolved(8).
+nameserver 127.0.0.53
+options edns0 trust-ad
+search corp.example.com example.com
+`
+
+const upstreamResolvConf = `# This is /run/systemd/resolve/resolv.conf managed by man:systemd-resolved(8).
+nameserver 10.0.0.2
+nameserver 10.0.0.3
+search corp.example.com example.com
+`
+
+const nsswitchConf = `# /etc/nsswitch.conf
+passwd:         files systemd
+hosts:          files mdns4_minimal [NOTFOUND=return] dns # trailing comment
+networks:       files
+`
+
+func TestParseResolvConf(t *testing.T) {
+	rc, err := ParseResolvConf(strings.NewReader(`
+nameserver 192.168.1.1
+nameserver 8.8.8.8 ; second server
+domain home.lan
+options ndots:2 timeout:1 attempts:3 rotate single-request
+`))
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"192.168.1.1", "8.8.8.8"}, rc.Nameservers)
+	require.Equal(t, "home.lan", rc.Domain)
+	require.Equal(t, []string{"home.lan"}, rc.Search)
+	require.Equal(t, 2, rc.Ndots)
+	require.Equal(t, 1, rc.Timeout)
+	require.Equal(t, 3, rc.Attempts)
+	require.True(t, rc.Rotate)
+	require.Equal(t, []string{"ndots:2", "timeout:1", "attempts:3", "rotate", "single-request"}, rc.Options)
+}
+
+func TestParseResolvConfDefaults(t *testing.T) {
+	rc, err := ParseResolvConf(strings.NewReader("nameserver 1.1.1.1\n"))
+
+	require.NoError(t, err)
+	require.Equal(t, DefaultNdots, rc.Ndots)
+	require.Equal(t, DefaultTimeout, rc.Timeout)
+	require.Equal(t, DefaultAttempts, rc.Attempts)
+	require.False(t, rc.Rotate)
+	require.Empty(t, rc.Search)
+}
+
+func TestParseResolvConfLastOfSearchAndDomainWins(t *testing.T) {
+	rc, err := ParseResolvConf(strings.NewReader("domain a.example\nsearch b.example c.example\n"))
+
+	require.NoError(t, err)
+	require.Equal(t, "", rc.Domain)
+	require.Equal(t, []string{"b.example", "c.example"}, rc.Search)
+}
+
+func TestParseNsswitchHosts(t *testing.T) {
+	hosts, err := ParseNsswitchHosts(strings.NewReader(nsswitchConf))
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"files", "mdns4_minimal", "[NOTFOUND=return]", "dns"}, hosts)
+}
+
+func TestReadSystemdResolvedStub(t *testing.T) {
+	dir := t.TempDir()
+	paths := Paths{
+		ResolvConf:        filepath.Join(dir, "resolv.conf"),
+		SystemdResolvConf: filepath.Join(dir, "upstream-resolv.conf"),
+		Nsswitch:          filepath.Join(dir, "nsswitch.conf"),
+	}
+
+	require.NoError(t, os.WriteFile(paths.ResolvConf, []byte(stubResolvConf), 0o644))
+	require.NoError(t, os.WriteFile(paths.SystemdResolvConf, []byte(upstreamResolvConf), 0o644))
+	require.NoError(t, os.WriteFile(paths.Nsswitch, []byte(nsswitchConf), 0o644))
+
+	cfg, err := Read(paths)
+
+	require.NoError(t, err)
+	require.True(t, cfg.SystemdResolvedStub)
+	require.Equal(t, []string{"127.0.0.53"}, cfg.Nameservers)
+	require.Equal(t, []string{"10.0.0.2", "10.0.0.3"}, cfg.UpstreamNameservers)
+	require.Equal(t, []string{"corp.example.com", "example.com"}, cfg.Search)
+	require.Equal(t, "dns", cfg.NsswitchHosts[len(cfg.NsswitchHosts)-1])
+}
+
+func TestReadDetectsStubBySymlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Symbolic links require elevation on Windows")
+	}
+
+	dir := t.TempDir()
+	stub := filepath.Join(dir, "stub-resolv.conf")
+	require.NoError(t, os.WriteFile(stub, []byte("nameserver 10.10.10.10\n"), 0o644))
+	require.NoError(t, os.Symlink(stub, filepath.Join(dir, "resolv.conf")))
+
+	cfg, err := Read(Paths{
+		ResolvConf:        filepath.Join(dir, "resolv.conf"),
+		SystemdResolvConf: filepath.Join(dir, "missing"),
+		Nsswitch:          filepath.Join(dir, "missing"),
+	})
+
+	require.NoError(t, err)
+	require.True(t, cfg.SystemdResolvedStub)
+	require.Nil(t, cfg.UpstreamNameservers)
+	require.Nil(t, cfg.NsswitchHosts)
+}
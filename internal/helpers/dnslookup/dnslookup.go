@@ -0,0 +1,415 @@
+package dnslookup
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// Record types supported by Lookup.
+var recordTypes = map[string]dnsmessage.Type{
+	"A":     dnsmessage.TypeA,
+	"AAAA":  dnsmessage.TypeAAAA,
+	"CNAME": dnsmessage.TypeCNAME,
+	"TXT":   dnsmessage.TypeTXT,
+	"MX":    dnsmessage.TypeMX,
+	"SRV":   dnsmessage.TypeSRV,
+	"PTR":   dnsmessage.TypePTR,
+}
+
+// Conventional names of response codes.
+var rcodeNames = map[dnsmessage.RCode]string{
+	dnsmessage.RCodeFormatError:    "FORMERR",
+	dnsmessage.RCodeServerFailure:  "SERVFAIL",
+	dnsmessage.RCodeNameError:      "NXDOMAIN",
+	dnsmessage.RCodeNotImplemented: "NOTIMP",
+	dnsmessage.RCodeRefused:        "REFUSED",
+}
+
+const maxUDPMessageSize = 4096
+
+type Record struct {
+	Name  string
+	Type  string
+	Value string
+
+	// TTL is nil when the record was obtained from a resolver that does not expose TTLs.
+	TTL *uint32
+}
+
+// SupportedTypes returns the record types that may be passed to Lookup.
+func SupportedTypes() []string {
+	result := make([]string, 0, len(recordTypes))
+
+	for t := range recordTypes {
+		result = append(result, t)
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// Lookup queries each of servers in turn for records of the given type,
+// returning the answers from the first server that responds.
+// Servers may be given as "host" or "host:port".
+func Lookup(ctx context.Context, servers []string, name, recordType string, timeout time.Duration) ([]*Record, error) {
+	recordType = strings.ToUpper(recordType)
+	qtype, ok := recordTypes[recordType]
+
+	if !ok {
+		return nil, fmt.Errorf("unsupported record type %q", recordType)
+	}
+
+	qname, err := queryName(name, qtype)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(servers) == 0 {
+		return nil, errors.New("no name servers to query")
+	}
+
+	var lastErr error
+
+	for _, server := range servers {
+		if _, _, err := net.SplitHostPort(server); err != nil {
+			server = net.JoinHostPort(server, "53")
+		}
+
+		msg, err := exchange(ctx, server, qname, qtype, timeout)
+
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if msg.RCode != dnsmessage.RCodeSuccess {
+			return nil, fmt.Errorf("query for %s %s returned %s", recordType, name, rcodeName(msg.RCode))
+		}
+
+		return answers(msg, qtype, recordType), nil
+	}
+
+	return nil, lastErr
+}
+
+// LookupSystem resolves the name as other programs on the machine do. The Go
+// resolver defers to the operating system on Windows and macOS, so that split
+// DNS configuration is used, and elsewhere follows the hosts file, nsswitch.conf
+// and the search domains of resolv.conf. TTLs are not available by this method.
+func LookupSystem(ctx context.Context, name, recordType string, timeout time.Duration) ([]*Record, error) {
+	recordType = strings.ToUpper(recordType)
+	qtype, ok := recordTypes[recordType]
+
+	if !ok {
+		return nil, fmt.Errorf("unsupported record type %q", recordType)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	r := net.DefaultResolver
+	result := make([]*Record, 0, 4)
+	add := func(value string) {
+		result = append(result, &Record{Name: fqdn(name), Type: recordType, Value: value})
+	}
+
+	switch qtype {
+	case dnsmessage.TypeA, dnsmessage.TypeAAAA:
+		network := "ip4"
+
+		if qtype == dnsmessage.TypeAAAA {
+			network = "ip6"
+		}
+
+		ips, err := r.LookupIP(ctx, network, name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ip := range ips {
+			add(ip.String())
+		}
+
+	case dnsmessage.TypeCNAME:
+		cname, err := r.LookupCNAME(ctx, name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if cname != fqdn(name) {
+			add(cname)
+		}
+
+	case dnsmessage.TypeTXT:
+		txts, err := r.LookupTXT(ctx, name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, txt := range txts {
+			add(txt)
+		}
+
+	case dnsmessage.TypeMX:
+		mxs, err := r.LookupMX(ctx, name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, mx := range mxs {
+			add(fmt.Sprintf("%d %s", mx.Pref, mx.Host))
+		}
+
+	case dnsmessage.TypeSRV:
+		_, srvs, err := r.LookupSRV(ctx, "", "", name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, srv := range srvs {
+			add(fmt.Sprintf("%d %d %d %s", srv.Priority, srv.Weight, srv.Port, srv.Target))
+		}
+
+	case dnsmessage.TypePTR:
+		names, err := r.LookupAddr(ctx, name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, n := range names {
+			add(n)
+		}
+	}
+
+	sortRecords(result)
+	return result, nil
+}
+
+// queryName returns the fully qualified name to query.
+// For PTR queries an IP address is converted to its reverse lookup name.
+func queryName(name string, qtype dnsmessage.Type) (dnsmessage.Name, error) {
+	if qtype == dnsmessage.TypePTR {
+		if ip := net.ParseIP(name); ip != nil {
+			name = reverseName(ip)
+		}
+	}
+
+	return dnsmessage.NewName(fqdn(name))
+}
+
+func reverseName(ip net.IP) string {
+	var sb strings.Builder
+
+	if ip4 := ip.To4(); ip4 != nil {
+		for i := len(ip4) - 1; i >= 0; i-- {
+			sb.WriteString(strconv.Itoa(int(ip4[i])))
+			sb.WriteByte('.')
+		}
+
+		sb.WriteString("in-addr.arpa.")
+		return sb.String()
+	}
+
+	const hexDigits = "0123456789abcdef"
+
+	for i := len(ip) - 1; i >= 0; i-- {
+		sb.WriteByte(hexDigits[ip[i]&0x0f])
+		sb.WriteByte('.')
+		sb.WriteByte(hexDigits[ip[i]>>4])
+		sb.WriteByte('.')
+	}
+
+	sb.WriteString("ip6.arpa.")
+	return sb.String()
+}
+
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+
+	return name + "."
+}
+
+// exchange sends the query over UDP, retrying over TCP if the response is truncated.
+func exchange(ctx context.Context, server string, qname dnsmessage.Name, qtype dnsmessage.Type, timeout time.Duration) (*dnsmessage.Message, error) {
+	var idBytes [2]byte
+
+	if _, err := rand.Read(idBytes[:]); err != nil {
+		return nil, err
+	}
+
+	query := dnsmessage.Message{
+		Header: dnsmessage.Header{
+			ID:               binary.BigEndian.Uint16(idBytes[:]),
+			RecursionDesired: true,
+		},
+		Questions: []dnsmessage.Question{
+			{
+				Name:  qname,
+				Type:  qtype,
+				Class: dnsmessage.ClassINET,
+			},
+		},
+	}
+
+	packed, err := query.Pack()
+
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := exchangeOver(ctx, "udp", server, packed, query.ID, timeout)
+
+	if err == nil && msg.Truncated {
+		msg, err = exchangeOver(ctx, "tcp", server, packed, query.ID, timeout)
+	}
+
+	return msg, err
+}
+
+func exchangeOver(ctx context.Context, network, server string, query []byte, id uint16, timeout time.Duration) (*dnsmessage.Message, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, network, server)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf []byte
+
+	if network == "tcp" {
+		// Messages over TCP are prefixed with a two byte length
+		prefixed := make([]byte, 2+len(query))
+		binary.BigEndian.PutUint16(prefixed, uint16(len(query)))
+		copy(prefixed[2:], query)
+
+		if _, err = conn.Write(prefixed); err != nil {
+			return nil, err
+		}
+
+		var length [2]byte
+
+		if _, err = io.ReadFull(conn, length[:]); err != nil {
+			return nil, err
+		}
+
+		buf = make([]byte, binary.BigEndian.Uint16(length[:]))
+
+		if _, err = io.ReadFull(conn, buf); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err = conn.Write(query); err != nil {
+			return nil, err
+		}
+
+		buf = make([]byte, maxUDPMessageSize)
+		n, err := conn.Read(buf)
+
+		if err != nil {
+			return nil, err
+		}
+
+		buf = buf[:n]
+	}
+
+	var msg dnsmessage.Message
+
+	if err = msg.Unpack(buf); err != nil {
+		return nil, err
+	}
+
+	if msg.ID != id || !msg.Response {
+		return nil, fmt.Errorf("unexpected response from %s", server)
+	}
+
+	return &msg, nil
+}
+
+// answers extracts the records of the queried type from the answer section.
+// Other records, e.g. the CNAME chain leading to an A record, are omitted.
+func answers(msg *dnsmessage.Message, qtype dnsmessage.Type, recordType string) []*Record {
+	result := make([]*Record, 0, len(msg.Answers))
+
+	for _, rr := range msg.Answers {
+		if rr.Header.Type != qtype {
+			continue
+		}
+
+		var value string
+
+		switch body := rr.Body.(type) {
+		case *dnsmessage.AResource:
+			value = net.IP(body.A[:]).String()
+		case *dnsmessage.AAAAResource:
+			value = net.IP(body.AAAA[:]).String()
+		case *dnsmessage.CNAMEResource:
+			value = body.CNAME.String()
+		case *dnsmessage.PTRResource:
+			value = body.PTR.String()
+		case *dnsmessage.TXTResource:
+			value = strings.Join(body.TXT, "")
+		case *dnsmessage.MXResource:
+			value = fmt.Sprintf("%d %s", body.Pref, body.MX.String())
+		case *dnsmessage.SRVResource:
+			value = fmt.Sprintf("%d %d %d %s", body.Priority, body.Weight, body.Port, body.Target.String())
+		default:
+			continue
+		}
+
+		ttl := rr.Header.TTL
+		result = append(result, &Record{
+			Name:  rr.Header.Name.String(),
+			Type:  recordType,
+			Value: value,
+			TTL:   &ttl,
+		})
+	}
+
+	sortRecords(result)
+	return result
+}
+
+func sortRecords(records []*Record) {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Value < records[j].Value
+	})
+}
+
+func rcodeName(rcode dnsmessage.RCode) string {
+	if n, ok := rcodeNames[rcode]; ok {
+		return n
+	}
+
+	return fmt.Sprintf("RCODE%d", rcode)
+}
@@ -0,0 +1,219 @@
+package dnslookup
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// stubServer is a minimal DNS server that answers from a fixed set of records,
+// over both UDP and TCP on the same port.
+type stubServer struct {
+	mu       sync.Mutex
+	udp      net.PacketConn
+	tcp      net.Listener
+	records  map[dnsmessage.Question][]dnsmessage.Resource
+	truncate bool
+}
+
+// If truncate is set, UDP responses have the TC bit set and no answers.
+func newStubServer(t *testing.T, truncate bool) *stubServer {
+	udp, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	tcp, err := net.Listen("tcp", udp.LocalAddr().String())
+	require.NoError(t, err)
+
+	s := &stubServer{
+		udp:      udp,
+		tcp:      tcp,
+		records:  make(map[dnsmessage.Question][]dnsmessage.Resource),
+		truncate: truncate,
+	}
+
+	t.Cleanup(func() {
+		udp.Close()
+		tcp.Close()
+	})
+
+	go s.serveUDP()
+	go s.serveTCP()
+
+	return s
+}
+
+func (s *stubServer) addr() string {
+	return s.udp.LocalAddr().String()
+}
+
+func (s *stubServer) add(name string, qtype dnsmessage.Type, ttl uint32, body dnsmessage.ResourceBody) {
+	q := dnsmessage.Question{
+		Name:  dnsmessage.MustNewName(name),
+		Type:  qtype,
+		Class: dnsmessage.ClassINET,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[q] = append(s.records[q], dnsmessage.Resource{
+		Header: dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: q.Class, TTL: ttl},
+		Body:   body,
+	})
+}
+
+func (s *stubServer) respond(query []byte, truncate bool) []byte {
+	var msg dnsmessage.Message
+
+	if err := msg.Unpack(query); err != nil || len(msg.Questions) != 1 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	msg.Response = true
+	answers, ok := s.records[msg.Questions[0]]
+
+	switch {
+	case truncate:
+		msg.Truncated = true
+	case ok:
+		msg.Answers = answers
+	default:
+		msg.RCode = dnsmessage.RCodeNameError
+	}
+
+	b, _ := msg.Pack()
+	return b
+}
+
+func (s *stubServer) serveUDP() {
+	buf := make([]byte, 512)
+
+	for {
+		n, addr, err := s.udp.ReadFrom(buf)
+
+		if err != nil {
+			return
+		}
+
+		_, _ = s.udp.WriteTo(s.respond(buf[:n], s.truncate), addr)
+	}
+}
+
+func (s *stubServer) serveTCP() {
+	for {
+		conn, err := s.tcp.Accept()
+
+		if err != nil {
+			return
+		}
+
+		var length [2]byte
+
+		if _, err = io.ReadFull(conn, length[:]); err == nil {
+			query := make([]byte, binary.BigEndian.Uint16(length[:]))
+
+			if _, err = io.ReadFull(conn, query); err == nil {
+				resp := s.respond(query, false)
+				binary.BigEndian.PutUint16(length[:], uint16(len(resp)))
+				_, _ = conn.Write(append(length[:], resp...))
+			}
+		}
+
+		conn.Close()
+	}
+}
+
+func TestLookupA(t *testing.T) {
+	s := newStubServer(t, false)
+	s.add("www.corp.example.", dnsmessage.TypeA, 300, &dnsmessage.AResource{A: [4]byte{10, 0, 0, 2}})
+	s.add("www.corp.example.", dnsmessage.TypeA, 300, &dnsmessage.AResource{A: [4]byte{10, 0, 0, 1}})
+
+	records, err := Lookup(context.Background(), []string{s.addr()}, "www.corp.example", "a", time.Second)
+
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, "10.0.0.1", records[0].Value)
+	require.Equal(t, "10.0.0.2", records[1].Value)
+	require.Equal(t, "A", records[0].Type)
+	require.Equal(t, "www.corp.example.", records[0].Name)
+	require.Equal(t, uint32(300), *records[0].TTL)
+}
+
+func TestLookupMXAndSRV(t *testing.T) {
+	s := newStubServer(t, false)
+	s.add("corp.example.", dnsmessage.TypeMX, 60, &dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName("mail.corp.example.")})
+	s.add("_ldap._tcp.corp.example.", dnsmessage.TypeSRV, 60, &dnsmessage.SRVResource{Priority: 0, Weight: 5, Port: 389, Target: dnsmessage.MustNewName("dc1.corp.example.")})
+
+	records, err := Lookup(context.Background(), []string{s.addr()}, "corp.example.", "MX", time.Second)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, "10 mail.corp.example.", records[0].Value)
+
+	records, err = Lookup(context.Background(), []string{s.addr()}, "_ldap._tcp.corp.example", "SRV", time.Second)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, "0 5 389 dc1.corp.example.", records[0].Value)
+}
+
+func TestLookupPTRFromAddress(t *testing.T) {
+	s := newStubServer(t, false)
+	s.add("1.0.0.10.in-addr.arpa.", dnsmessage.TypePTR, 60, &dnsmessage.PTRResource{PTR: dnsmessage.MustNewName("www.corp.example.")})
+
+	records, err := Lookup(context.Background(), []string{s.addr()}, "10.0.0.1", "PTR", time.Second)
+
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, "www.corp.example.", records[0].Value)
+}
+
+func TestLookupTruncatedFallsBackToTCP(t *testing.T) {
+	s := newStubServer(t, true)
+	s.add("big.corp.example.", dnsmessage.TypeTXT, 60, &dnsmessage.TXTResource{TXT: []string{"v=spf1 ", "-all"}})
+
+	records, err := Lookup(context.Background(), []string{s.addr()}, "big.corp.example", "TXT", time.Second)
+
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, "v=spf1 -all", records[0].Value)
+}
+
+func TestLookupNXDomain(t *testing.T) {
+	s := newStubServer(t, false)
+
+	_, err := Lookup(context.Background(), []string{s.addr()}, "missing.corp.example", "A", time.Second)
+
+	require.ErrorContains(t, err, "NXDOMAIN")
+}
+
+func TestLookupUnsupportedType(t *testing.T) {
+	_, err := Lookup(context.Background(), []string{"127.0.0.1"}, "corp.example", "HINFO", time.Second)
+
+	require.ErrorContains(t, err, "unsupported record type")
+}
+
+func TestLookupSystemUsesHostsFile(t *testing.T) {
+	// localhost is in the hosts file of every system, and is not sent to name servers
+	records, err := LookupSystem(context.Background(), "localhost", "A", time.Second)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, records)
+	require.Equal(t, "127.0.0.1", records[0].Value)
+	require.Nil(t, records[0].TTL)
+}
+
+func TestReverseNameIPv6(t *testing.T) {
+	require.Equal(t,
+		"1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.",
+		reverseName(net.ParseIP("2001:db8::1")),
+	)
+}
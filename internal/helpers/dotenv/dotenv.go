@@ -0,0 +1,469 @@
+package dotenv
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Lookup is the signature of os.LookupEnv.
+type Lookup func(key string) (string, bool)
+
+// SyntaxError reports a line of a file that cannot be parsed.
+type SyntaxError struct {
+	File string
+	Line int
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Msg)
+}
+
+// Parser reads dotenv files. When several files are parsed, they are layered
+// in order, so that values in later files override those in earlier ones.
+//
+// The syntax is that of the common dotenv implementations:
+//
+//	# Comment
+//	KEY=unquoted value    # Comment
+//	export KEY=value
+//	KEY='literal value, may span lines'
+//	KEY="value with \n escapes and ${VAR} references, may span lines"
+//
+// References take the forms $VAR, ${VAR}, ${VAR:-default} (used when VAR is
+// unset or empty) and ${VAR-default} (used when VAR is unset). They are
+// resolved against the keys already parsed, then against the environment.
+// Unquoted and double-quoted values are expanded; single-quoted values are not.
+type Parser struct {
+	env       Lookup
+	values    map[string]string
+	undefined map[string]bool
+}
+
+// New creates a parser that resolves references not defined in the files with env.
+func New(env Lookup) *Parser {
+	if env == nil {
+		env = func(string) (string, bool) { return "", false }
+	}
+
+	return &Parser{
+		env:       env,
+		values:    make(map[string]string),
+		undefined: make(map[string]bool),
+	}
+}
+
+// Values returns the values of all keys parsed so far.
+func (p *Parser) Values() map[string]string {
+	values := make(map[string]string, len(p.values))
+
+	for k, v := range p.values {
+		values[k] = v
+	}
+
+	return values
+}
+
+// Undefined returns the sorted names of variables that were referenced, but
+// were defined neither in the files nor in the environment. They expand to "".
+func (p *Parser) Undefined() []string {
+	names := make([]string, 0, len(p.undefined))
+
+	for n := range p.undefined {
+		names = append(names, n)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// ParseFile parses the file at path.
+func (p *Parser) ParseFile(path string) error {
+	b, err := os.ReadFile(path)
+
+	if err != nil {
+		return err
+	}
+
+	return p.Parse(path, string(b))
+}
+
+// Parse parses the content of a file. name is used in error messages.
+// If there is an error, none of the keys in the content are set.
+func (p *Parser) Parse(name, content string) error {
+	s := &scanner{
+		src:  strings.ReplaceAll(strings.TrimPrefix(content, "\ufeff"), "\r\n", "\n"),
+		line: 1,
+	}
+
+	// Parse into a new layer, so that an error leaves the values unchanged
+	layer := &Parser{
+		env:       p.lookup,
+		values:    make(map[string]string),
+		undefined: make(map[string]bool),
+	}
+
+	if err := layer.parse(s); err != nil {
+		return &SyntaxError{File: name, Line: s.errLine, Msg: err.Error()}
+	}
+
+	for k, v := range layer.values {
+		p.values[k] = v
+	}
+
+	for n := range layer.undefined {
+		p.undefined[n] = true
+	}
+
+	return nil
+}
+
+func (p *Parser) lookup(key string) (string, bool) {
+	if v, ok := p.values[key]; ok {
+		return v, true
+	}
+
+	return p.env(key)
+}
+
+// parse reads each assignment in turn.
+func (p *Parser) parse(s *scanner) error {
+	for {
+		s.skip(" \t\n")
+
+		if s.eof() {
+			return nil
+		}
+
+		s.errLine = s.line
+
+		if s.peek() == '#' {
+			s.skipLine()
+			continue
+		}
+
+		key := s.name()
+
+		if key == "export" && (s.peek() == ' ' || s.peek() == '\t') {
+			s.skip(" \t")
+			key = s.name()
+		}
+
+		if key == "" {
+			// The line is not quoted, as it may hold a secret
+			return fmt.Errorf("expected a key")
+		}
+
+		s.skip(" \t")
+
+		if s.peek() != '=' {
+			return fmt.Errorf("expected '=' after %s", key)
+		}
+
+		s.next()
+		s.skip(" \t")
+
+		value, err := p.value(s)
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+
+		p.values[key] = value
+	}
+}
+
+// value reads the value of an assignment, up to the end of its last line.
+func (p *Parser) value(s *scanner) (string, error) {
+	switch s.peek() {
+	case '\'':
+		s.next()
+		raw, ok := s.until('\'')
+
+		if !ok {
+			return "", fmt.Errorf("unterminated single-quoted value")
+		}
+
+		return raw, s.endOfValue()
+
+	case '"':
+		s.next()
+		start := s.pos
+
+		// Find the closing quote, skipping escaped characters
+		for !s.eof() && s.peek() != '"' {
+			if s.next() == '\\' && !s.eof() {
+				s.next()
+			}
+		}
+
+		if s.eof() {
+			return "", fmt.Errorf("unterminated double-quoted value")
+		}
+
+		raw := s.src[start:s.pos]
+		s.next()
+
+		value, err := p.expand(raw, true)
+
+		if err != nil {
+			return "", err
+		}
+
+		return value, s.endOfValue()
+
+	default:
+		raw := s.restOfLine()
+		s.skipLine()
+
+		// A comment must be preceded by whitespace, so that e.g. URL fragments are kept
+		if i := strings.Index(raw, " #"); i >= 0 {
+			raw = raw[:i]
+		}
+
+		if i := strings.Index(raw, "\t#"); i >= 0 {
+			raw = raw[:i]
+		}
+
+		return p.expand(strings.TrimSpace(raw), false)
+	}
+}
+
+// expand replaces variable references in a value, and if escapes is true,
+// also backslash escapes.
+func (p *Parser) expand(raw string, escapes bool) (string, error) {
+	var b strings.Builder
+
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+
+		switch {
+		case c == '\\' && escapes && i+1 < len(raw):
+			i++
+
+			switch raw[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case '\\', '"', '$':
+				b.WriteByte(raw[i])
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(raw[i])
+			}
+
+		case c == '$' && i+1 < len(raw) && raw[i+1] == '{':
+			end := matchingBrace(raw, i+1)
+
+			if end < 0 {
+				return "", fmt.Errorf("unterminated variable reference")
+			}
+
+			value, err := p.reference(raw[i+2 : end])
+
+			if err != nil {
+				return "", err
+			}
+
+			b.WriteString(value)
+			i = end
+
+		case c == '$' && i+1 < len(raw) && isNameStart(raw[i+1]):
+			j := i + 1
+
+			for j < len(raw) && isNameChar(raw[j]) {
+				j++
+			}
+
+			value, _ := p.resolve(raw[i+1 : j])
+			b.WriteString(value)
+			i = j - 1
+
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// reference resolves the content of ${...}.
+func (p *Parser) reference(expr string) (string, error) {
+	n := 0
+
+	for n < len(expr) && isNameChar(expr[n]) {
+		n++
+	}
+
+	name, rest := expr[:n], expr[n:]
+
+	if name == "" || !isNameStart(name[0]) {
+		return "", fmt.Errorf("invalid variable reference")
+	}
+
+	switch {
+	case rest == "":
+		value, _ := p.resolve(name)
+		return value, nil
+
+	case strings.HasPrefix(rest, ":-"):
+		if value, ok := p.lookup(name); ok && value != "" {
+			return value, nil
+		}
+
+		return p.expand(rest[2:], false)
+
+	case strings.HasPrefix(rest, "-"):
+		if value, ok := p.lookup(name); ok {
+			return value, nil
+		}
+
+		return p.expand(rest[1:], false)
+	}
+
+	return "", fmt.Errorf("invalid variable reference to %s", name)
+}
+
+// resolve gets the value of a variable, recording it if it is undefined.
+func (p *Parser) resolve(name string) (string, bool) {
+	value, ok := p.lookup(name)
+
+	if !ok {
+		p.undefined[name] = true
+	}
+
+	return value, ok
+}
+
+// matchingBrace returns the index of the '}' closing the '{' at open, or -1.
+func matchingBrace(s string, open int) int {
+	depth := 0
+
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z')
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
+
+// scanner tracks the position in the content of a file.
+type scanner struct {
+	src  string
+	pos  int
+	line int
+
+	// errLine is the line at which the current assignment starts
+	errLine int
+}
+
+func (s *scanner) eof() bool {
+	return s.pos >= len(s.src)
+}
+
+func (s *scanner) peek() byte {
+	if s.eof() {
+		return 0
+	}
+
+	return s.src[s.pos]
+}
+
+func (s *scanner) next() byte {
+	c := s.src[s.pos]
+	s.pos++
+
+	if c == '\n' {
+		s.line++
+	}
+
+	return c
+}
+
+// skip advances past any of the characters in chars.
+func (s *scanner) skip(chars string) {
+	for !s.eof() && strings.IndexByte(chars, s.peek()) >= 0 {
+		s.next()
+	}
+}
+
+// skipLine advances to the start of the next line.
+func (s *scanner) skipLine() {
+	for !s.eof() && s.next() != '\n' {
+	}
+}
+
+func (s *scanner) restOfLine() string {
+	if i := strings.IndexByte(s.src[s.pos:], '\n'); i >= 0 {
+		return s.src[s.pos : s.pos+i]
+	}
+
+	return s.src[s.pos:]
+}
+
+// name reads a key. Dots are allowed, as some tools use them in keys.
+func (s *scanner) name() string {
+	start := s.pos
+
+	if s.eof() || !isNameStart(s.peek()) {
+		return ""
+	}
+
+	for !s.eof() && (isNameChar(s.peek()) || s.peek() == '.') {
+		s.next()
+	}
+
+	return s.src[start:s.pos]
+}
+
+// until reads up to the next c, which is consumed.
+func (s *scanner) until(c byte) (string, bool) {
+	start := s.pos
+
+	for !s.eof() {
+		if s.peek() == c {
+			value := s.src[start:s.pos]
+			s.next()
+			return value, true
+		}
+
+		s.next()
+	}
+
+	return "", false
+}
+
+// endOfValue checks that nothing but a comment follows a quoted value.
+func (s *scanner) endOfValue() error {
+	s.skip(" \t")
+
+	if !s.eof() && s.peek() != '\n' && s.peek() != '#' {
+		return fmt.Errorf("unexpected text after quoted value")
+	}
+
+	s.skipLine()
+
+	return nil
+}
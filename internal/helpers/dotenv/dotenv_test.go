@@ -0,0 +1,127 @@
+package dotenv
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func env(vars map[string]string) Lookup {
+	return func(key string) (string, bool) {
+		v, ok := vars[key]
+		return v, ok
+	}
+}
+
+func TestParse(t *testing.T) {
+	p := New(env(map[string]string{"HOME": "/home/user", "EMPTY": ""}))
+
+	err := p.Parse(".env", "\ufeff"+`# Comment
+PLAIN=value
+SPACED = spaced value   # trailing comment
+export EXPORTED=1
+URL=https://example.com/#fragment
+EMPTY_VALUE=
+SINGLE='literal ${HOME} \n'
+DOUBLE="tab\there \"quoted\" \$HOME"
+MULTI="line 1
+line 2"
+MULTI_SINGLE='a
+b' # comment
+REF=${HOME}/app
+BARE=$HOME/bare
+EARLIER=${PLAIN}-${SINGLE}
+DEFAULT=${MISSING:-fallback}
+EMPTY_DEFAULT=${EMPTY:-fallback}
+UNSET_DEFAULT=${EMPTY-fallback}
+NESTED=${MISSING:-${HOME}/x}
+UNDEFINED=[$NOPE]
+DOLLAR=costs $5
+TOKEN=dGVzdA==
+dotted.key=1
+`)
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]string{
+		"PLAIN":         "value",
+		"SPACED":        "spaced value",
+		"EXPORTED":      "1",
+		"URL":           "https://example.com/#fragment",
+		"EMPTY_VALUE":   "",
+		"SINGLE":        `literal ${HOME} \n`,
+		"DOUBLE":        "tab\there \"quoted\" $HOME",
+		"MULTI":         "line 1\nline 2",
+		"MULTI_SINGLE":  "a\nb",
+		"REF":           "/home/user/app",
+		"BARE":          "/home/user/bare",
+		"EARLIER":       `value-literal ${HOME} \n`,
+		"DEFAULT":       "fallback",
+		"EMPTY_DEFAULT": "fallback",
+		"UNSET_DEFAULT": "",
+		"NESTED":        "/home/user/x",
+		"UNDEFINED":     "[]",
+		"DOLLAR":        "costs $5",
+		"TOKEN":         "dGVzdA==",
+		"dotted.key":    "1",
+	}, p.Values())
+
+	require.Equal(t, []string{"NOPE"}, p.Undefined())
+}
+
+func TestParseCRLF(t *testing.T) {
+	p := New(nil)
+	require.NoError(t, p.Parse(".env", "A=1\r\nB=\"x\r\ny\"\r\n"))
+	require.Equal(t, map[string]string{"A": "1", "B": "x\ny"}, p.Values())
+}
+
+func TestParseErrors(t *testing.T) {
+	for content, msg := range map[string]string{
+		"A=1\nB\n":                ".env:2: expected '=' after B",
+		"A=1\n=secret\n":          ".env:2: expected a key",
+		"A='secret\n":             ".env:1: A: unterminated single-quoted value",
+		"\nA=\"secret\n\n":        ".env:2: A: unterminated double-quoted value",
+		"A=\"abc\" secret\n":      ".env:1: A: unexpected text after quoted value",
+		"A=${B:-secret\n":         ".env:1: A: unterminated variable reference",
+		"A=${B:secret}\n":         ".env:1: A: invalid variable reference to B",
+		"A=${1secret}\n":          ".env:1: A: invalid variable reference",
+		"A=1\nexport\n":           ".env:2: expected '=' after export",
+		"A=1\n  # ok\n  B\n":      ".env:3:",
+		"A=\"${B:-x}\"secret\n":   ".env:1: A: unexpected text after quoted value",
+		"A=1\nsecret value\n":     ".env:2: expected '=' after secret",
+		"A=1\n'secret' = value\n": ".env:2: expected a key",
+	} {
+		p := New(nil)
+		err := p.Parse(".env", content)
+		require.ErrorContains(t, err, msg, content)
+		require.Empty(t, p.Values(), "values set despite error")
+
+		// Values may be secrets, so are never quoted in errors
+		if !strings.Contains(msg, "secret") {
+			require.NotContains(t, err.Error(), "secret", content)
+		}
+	}
+}
+
+func TestLayers(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, ".env")
+	local := filepath.Join(dir, ".env.local")
+	require.NoError(t, os.WriteFile(base, []byte("HOST=db\nPORT=5432\nURL=postgres://${HOST}:${PORT}\n"), 0o600))
+	require.NoError(t, os.WriteFile(local, []byte("HOST=localhost\nLOCAL_URL=postgres://${HOST}:${PORT}\n"), 0o600))
+
+	p := New(env(map[string]string{"HOST": "from-env"}))
+	require.NoError(t, p.ParseFile(base))
+	require.NoError(t, p.ParseFile(local))
+
+	require.Equal(t, map[string]string{
+		"HOST":      "localhost",
+		"PORT":      "5432",
+		"URL":       "postgres://db:5432",
+		"LOCAL_URL": "postgres://localhost:5432",
+	}, p.Values())
+
+	require.Error(t, p.ParseFile(filepath.Join(dir, "missing")))
+}
@@ -0,0 +1,449 @@
+package envfile
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/dotenv"
+)
+
+// Formats of file that can be written.
+const (
+	// FormatDotenv is a .env file, as read by docker compose, the dotenv libraries and localos_dotenv
+	FormatDotenv = "dotenv"
+
+	// FormatPosix is a script for POSIX shells, to be sourced with ". file"
+	FormatPosix = "posix"
+
+	// FormatPowerShell is a PowerShell script, to be dot sourced with ". file.ps1"
+	FormatPowerShell = "powershell"
+)
+
+// Formats are all the supported formats.
+var Formats = []string{FormatDotenv, FormatPosix, FormatPowerShell}
+
+// ValidName reports whether name can be used as a variable name in all formats.
+func ValidName(name string) bool {
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return false
+	}
+
+	for _, c := range name {
+		if c != '_' && (c < 'A' || c > 'Z') && (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Render produces the content of a file of the given format that sets vars,
+// in order of name. If header is not empty, each of its lines is written as
+// a comment at the start of the file.
+func Render(format string, vars map[string]string, header string) ([]byte, error) {
+	var quote func(string) string
+	var assign string
+
+	switch format {
+	case FormatDotenv:
+		quote, assign = quoteDotenv, "%s=%s\n"
+	case FormatPosix:
+		quote, assign = quotePosix, "export %s=%s\n"
+	case FormatPowerShell:
+		quote, assign = quotePowerShell, "$env:%s = %s\n"
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+
+	var b strings.Builder
+
+	if header != "" {
+		for _, line := range strings.Split(strings.TrimRight(header, "\n"), "\n") {
+			b.WriteString(strings.TrimRight("# "+line, " "))
+			b.WriteByte('\n')
+		}
+
+		b.WriteByte('\n')
+	}
+
+	names := make([]string, 0, len(vars))
+
+	for name := range vars {
+		if !ValidName(name) {
+			return nil, fmt.Errorf("invalid variable name %q", name)
+		}
+
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(&b, assign, name, quote(vars[name]))
+	}
+
+	return []byte(b.String()), nil
+}
+
+// Header returns the comment lines at the start of content without their "#",
+// which is the header given to Render, or "" if there are none.
+func Header(content []byte) string {
+	lines := make([]string, 0, 4)
+
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+
+		lines = append(lines, strings.TrimPrefix(strings.TrimPrefix(line, "#"), " "))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Parse reads the variables set by a file of the given format. It accepts
+// what Render produces, and the usual hand-written forms of each format.
+func Parse(format string, content []byte) (map[string]string, error) {
+	switch format {
+	case FormatDotenv:
+		p := dotenv.New(nil)
+
+		if err := p.Parse("", string(content)); err != nil {
+			return nil, err
+		}
+
+		return p.Values(), nil
+
+	case FormatPosix:
+		return parsePosix(string(content))
+
+	case FormatPowerShell:
+		return parsePowerShell(string(content))
+	}
+
+	return nil, fmt.Errorf("unsupported format %q", format)
+}
+
+// Detect guesses the format of a file from its content.
+func Detect(content []byte) string {
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(strings.ToLower(line), "$env:"), strings.HasPrefix(strings.ToLower(line), "${env:"):
+			return FormatPowerShell
+		case strings.HasPrefix(line, "export "):
+			return FormatPosix
+		}
+	}
+
+	return FormatDotenv
+}
+
+// quoteDotenv uses single quotes where possible, as the value is then taken
+// literally by all dotenv implementations. Otherwise double quotes are used,
+// with escapes for characters that would be interpreted.
+func quoteDotenv(value string) string {
+	if !strings.ContainsAny(value, "'\n\r") {
+		return "'" + value + "'"
+	}
+
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\n", `\n`, "\r", `\r`)
+
+	return `"` + r.Replace(value) + `"`
+}
+
+// quotePosix uses single quotes, within which nothing but a single quote is
+// special. A single quote is written by closing the quotes, escaping it, and reopening.
+func quotePosix(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// powerShellQuotes are the characters PowerShell accepts as single quotes.
+const powerShellQuotes = "'\u2018\u2019\u201a\u201b"
+
+// powerShellDoubleQuotes are the characters PowerShell accepts as double quotes.
+const powerShellDoubleQuotes = "\"\u201c\u201d\u201e"
+
+// quotePowerShell uses a verbatim string, within which a quote is escaped by doubling it.
+func quotePowerShell(value string) string {
+	var b strings.Builder
+
+	b.WriteByte('\'')
+
+	for _, c := range value {
+		if strings.ContainsRune(powerShellQuotes, c) {
+			b.WriteRune(c)
+		}
+
+		b.WriteRune(c)
+	}
+
+	b.WriteByte('\'')
+
+	return b.String()
+}
+
+// parsePosix reads "[export] NAME=word" lines, where word is made of
+// single-quoted, double-quoted, backslash-escaped and plain characters.
+func parsePosix(content string) (map[string]string, error) {
+	vars := make(map[string]string)
+	s := []rune(content)
+	line := 1
+
+	for i := 0; i < len(s); {
+		// Skip blank lines and comments
+		for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\r' || s[i] == '\n' || s[i] == ';') {
+			if s[i] == '\n' {
+				line++
+			}
+
+			i++
+		}
+
+		if i >= len(s) {
+			break
+		}
+
+		if s[i] == '#' {
+			for i < len(s) && s[i] != '\n' {
+				i++
+			}
+
+			continue
+		}
+
+		start := line
+		stmt := i
+
+		for i < len(s) && s[i] != '=' && s[i] != '\r' && s[i] != '\n' {
+			i++
+		}
+
+		name := strings.TrimSpace(string(s[stmt:i]))
+		name = strings.TrimSpace(strings.TrimPrefix(name, "export "))
+
+		if i >= len(s) || s[i] != '=' || !ValidName(name) {
+			return nil, fmt.Errorf("line %d: expected [export] NAME=value", start)
+		}
+
+		i++
+
+		var b strings.Builder
+
+	word:
+		for i < len(s) {
+			switch c := s[i]; c {
+			case ' ', '\t', '\r', '\n', ';':
+				break word
+
+			case '\'':
+				i++
+
+				for i < len(s) && s[i] != '\'' {
+					if s[i] == '\n' {
+						line++
+					}
+
+					b.WriteRune(s[i])
+					i++
+				}
+
+				if i >= len(s) {
+					return nil, fmt.Errorf("line %d: unterminated single-quoted value", start)
+				}
+
+				i++
+
+			case '"':
+				i++
+
+				for i < len(s) && s[i] != '"' {
+					if s[i] == '\\' && i+1 < len(s) && strings.ContainsRune("$`\"\\\n", s[i+1]) {
+						i++
+
+						if s[i] == '\n' {
+							// Line continuation
+							line++
+							i++
+							continue
+						}
+					}
+
+					if s[i] == '\n' {
+						line++
+					}
+
+					b.WriteRune(s[i])
+					i++
+				}
+
+				if i >= len(s) {
+					return nil, fmt.Errorf("line %d: unterminated double-quoted value", start)
+				}
+
+				i++
+
+			case '\\':
+				i++
+
+				if i < len(s) {
+					if s[i] == '\n' {
+						line++
+					} else {
+						b.WriteRune(s[i])
+					}
+
+					i++
+				}
+
+			default:
+				b.WriteRune(c)
+				i++
+			}
+		}
+
+		// Anything after the value up to the end of the line must be a comment
+		for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\r') {
+			i++
+		}
+
+		if i < len(s) && s[i] != '\n' && s[i] != '#' && s[i] != ';' {
+			return nil, fmt.Errorf("line %d: unexpected characters after value", line)
+		}
+
+		vars[name] = b.String()
+	}
+
+	return vars, nil
+}
+
+// parsePowerShell reads "$env:NAME = 'value'" lines. Double-quoted values are
+// accepted if they contain no expressions.
+func parsePowerShell(content string) (map[string]string, error) {
+	vars := make(map[string]string)
+	s := []rune(content)
+	line := 1
+
+	for i := 0; i < len(s); {
+		for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\r' || s[i] == '\n' || s[i] == ';') {
+			if s[i] == '\n' {
+				line++
+			}
+
+			i++
+		}
+
+		if i >= len(s) {
+			break
+		}
+
+		if s[i] == '#' {
+			for i < len(s) && s[i] != '\n' {
+				i++
+			}
+
+			continue
+		}
+
+		start := line
+		stmt := i
+
+		for i < len(s) && s[i] != '=' && s[i] != '\r' && s[i] != '\n' {
+			i++
+		}
+
+		target := strings.TrimSpace(string(s[stmt:i]))
+		name, ok := powerShellEnvName(target)
+
+		if i >= len(s) || s[i] != '=' || !ok {
+			return nil, fmt.Errorf("line %d: expected $env:NAME = 'value'", start)
+		}
+
+		i++
+
+		for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\r') {
+			i++
+		}
+
+		if i >= len(s) || !strings.ContainsRune(powerShellQuotes+powerShellDoubleQuotes, s[i]) {
+			return nil, fmt.Errorf("line %d: expected a quoted value", start)
+		}
+
+		double := !strings.ContainsRune(powerShellQuotes, s[i])
+		isQuote := func(c rune) bool {
+			if double {
+				return strings.ContainsRune(powerShellDoubleQuotes, c)
+			}
+
+			return strings.ContainsRune(powerShellQuotes, c)
+		}
+
+		i++
+
+		var b strings.Builder
+
+		for {
+			if i >= len(s) {
+				return nil, fmt.Errorf("line %d: unterminated string", start)
+			}
+
+			c := s[i]
+
+			if c == '\n' {
+				line++
+			}
+
+			if isQuote(c) {
+				if i+1 < len(s) && isQuote(s[i+1]) {
+					// Doubled quote
+					b.WriteRune(c)
+					i += 2
+					continue
+				}
+
+				i++
+				break
+			}
+
+			if double && (c == '$' || c == '`') {
+				return nil, fmt.Errorf("line %d: expressions and escapes in double-quoted strings are not supported", line)
+			}
+
+			b.WriteRune(c)
+			i++
+		}
+
+		for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\r') {
+			i++
+		}
+
+		if i < len(s) && s[i] != '\n' && s[i] != '#' && s[i] != ';' {
+			return nil, fmt.Errorf("line %d: unexpected characters after value", line)
+		}
+
+		vars[name] = b.String()
+	}
+
+	return vars, nil
+}
+
+// powerShellEnvName gets NAME from $env:NAME or ${env:NAME}.
+func powerShellEnvName(target string) (string, bool) {
+	lower := strings.ToLower(target)
+
+	switch {
+	case strings.HasPrefix(lower, "$env:"):
+		name := target[len("$env:"):]
+		return name, ValidName(name)
+
+	case strings.HasPrefix(lower, "${env:") && strings.HasSuffix(target, "}"):
+		name := target[len("${env:") : len(target)-1]
+		return name, name != ""
+	}
+
+	return "", false
+}
@@ -0,0 +1,111 @@
+package envfile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var tricky = map[string]string{
+	"EMPTY":     "",
+	"PLAIN":     "value",
+	"SPACES":    "  leading and trailing  ",
+	"SINGLE":    "it's",
+	"DOUBLE":    `say "hi"`,
+	"DOLLAR":    "$HOME ${HOME} $(id) `id`",
+	"BACKSLASH": `C:\Users\me\n`,
+	"NEWLINES":  "line 1\nline 2\r\n",
+	"HASH":      "value # not a comment",
+	"SMART":     "it‘s ’quoted’ “too”",
+	"EQUALS":    "dGVzdA==",
+	"UNICODE":   "héllo wörld ✓",
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range Formats {
+		t.Run(format, func(t *testing.T) {
+			content, err := Render(format, tricky, "Generated by terraform\n\nDo not edit")
+			require.NoError(t, err)
+
+			vars, err := Parse(format, content)
+			require.NoError(t, err, string(content))
+			require.Equal(t, tricky, vars)
+			require.Equal(t, format, Detect(content))
+			require.Equal(t, "Generated by terraform\n\nDo not edit", Header(content))
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	vars := map[string]string{"B": "it's", "A": "x"}
+
+	content, err := Render(FormatDotenv, vars, "header")
+	require.NoError(t, err)
+	require.Equal(t, "# header\n\nA='x'\nB=\"it's\"\n", string(content))
+
+	content, err = Render(FormatPosix, vars, "")
+	require.NoError(t, err)
+	require.Equal(t, "export A='x'\nexport B='it'\\''s'\n", string(content))
+
+	content, err = Render(FormatPowerShell, vars, "")
+	require.NoError(t, err)
+	require.Equal(t, "$env:A = 'x'\n$env:B = 'it''s'\n", string(content))
+
+	_, err = Render(FormatPosix, map[string]string{"NOT-VALID": "x"}, "")
+	require.ErrorContains(t, err, "invalid variable name")
+
+	_, err = Render("yaml", vars, "")
+	require.Error(t, err)
+}
+
+func TestParseHandWritten(t *testing.T) {
+	vars, err := Parse(FormatPosix, []byte(`# comment
+export A=plain # comment
+B="double \"quoted\" \$x"; C=a'b c'\ d
+`))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"A": "plain", "B": `double "quoted" $x`, "C": "ab c d"}, vars)
+
+	vars, err = Parse(FormatPowerShell, []byte(`# comment
+$Env:A = "plain"
+${env:B-C} = 'x'
+`))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"A": "plain", "B-C": "x"}, vars)
+
+	for format, content := range map[string]string{
+		FormatPosix:      "export A='unterminated\n",
+		FormatPowerShell: "$env:A = \"$(Get-Date)\"\n",
+	} {
+		_, err = Parse(format, []byte(content))
+		require.Error(t, err, content)
+	}
+
+	_, err = Parse(FormatPowerShell, []byte("Write-Host hello\n"))
+	require.ErrorContains(t, err, "line 1")
+}
+
+func TestValidName(t *testing.T) {
+	require.True(t, ValidName("_A1"))
+	require.False(t, ValidName("1A"))
+	require.False(t, ValidName("A-B"))
+	require.False(t, ValidName(""))
+}
+
+func TestParseCRLF(t *testing.T) {
+	for format, content := range map[string]string{
+		FormatDotenv:     "# header\r\nA='x'\r\nB=\"y\"\r\n",
+		FormatPosix:      "# header\r\nexport A='x'\r\nexport B=y\r\n",
+		FormatPowerShell: "# header\r\n$env:A = 'x'\r\n$env:B = 'y'\r\n",
+	} {
+		vars, err := Parse(format, []byte(content))
+		require.NoError(t, err, format)
+		require.Equal(t, map[string]string{"A": "x", "B": "y"}, vars, format)
+	}
+}
+
+func TestHeader(t *testing.T) {
+	require.Equal(t, "", Header([]byte("A=1\n# not a header\n")))
+	require.Equal(t, "", Header(nil))
+	require.Equal(t, "edited\n#indented", Header([]byte("# edited\r\n##indented\r\nA=1\r\n")))
+}
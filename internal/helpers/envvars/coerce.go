@@ -0,0 +1,68 @@
+package envvars
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Types are the types a variable value can be converted to.
+var Types = []string{"string", "bool", "number", "list", "json"}
+
+// DefaultSeparator separates the items of a list value.
+const DefaultSeparator = ","
+
+// ParseBool converts a value to a boolean. As well as the forms accepted by
+// strconv.ParseBool, "yes", "no", "on" and "off" are accepted, in any case.
+func ParseBool(s string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "1", "t", "true", "y", "yes", "on":
+		return true, nil
+	case "0", "f", "false", "n", "no", "off":
+		return false, nil
+	}
+
+	return false, fmt.Errorf("must be a boolean (true/false, yes/no, on/off or 1/0)")
+}
+
+// ParseNumber converts a value to a finite decimal number.
+func ParseNumber(s string) (*big.Float, error) {
+	f, _, err := big.ParseFloat(strings.TrimSpace(s), 10, 512, big.ToNearestEven)
+
+	if err != nil || f.IsInf() {
+		return nil, fmt.Errorf("must be a number")
+	}
+
+	return f, nil
+}
+
+// SplitList splits a value into a list at each separator. Whitespace around
+// items is removed, and empty items are dropped.
+func SplitList(s, sep string) []string {
+	if sep == "" {
+		sep = DefaultSeparator
+	}
+
+	items := make([]string, 0, strings.Count(s, sep)+1)
+
+	for _, item := range strings.Split(s, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// CompactJSON checks that a value is JSON, and returns it without insignificant whitespace.
+func CompactJSON(s string) (string, error) {
+	var buf bytes.Buffer
+
+	if err := json.Compact(&buf, []byte(s)); err != nil {
+		return "", fmt.Errorf("must be JSON: %w", err)
+	}
+
+	return buf.String(), nil
+}
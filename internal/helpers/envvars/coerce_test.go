@@ -0,0 +1,58 @@
+package envvars
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBool(t *testing.T) {
+	for _, s := range []string{"1", "true", "TRUE", "yes", "On", " y "} {
+		b, err := ParseBool(s)
+		require.NoError(t, err, s)
+		require.True(t, b, s)
+	}
+
+	for _, s := range []string{"0", "false", "No", "OFF", "f"} {
+		b, err := ParseBool(s)
+		require.NoError(t, err, s)
+		require.False(t, b, s)
+	}
+
+	_, err := ParseBool("maybe")
+	require.Error(t, err)
+
+	_, err = ParseBool("")
+	require.Error(t, err)
+}
+
+func TestParseNumber(t *testing.T) {
+	f, err := ParseNumber(" 8080 ")
+	require.NoError(t, err)
+	require.Equal(t, "8080", f.Text('f', -1))
+
+	f, err = ParseNumber("-1.5e3")
+	require.NoError(t, err)
+	require.Equal(t, "-1500", f.Text('f', -1))
+
+	for _, s := range []string{"", "abc", "0x10", "Inf", "1,000"} {
+		_, err = ParseNumber(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	require.Equal(t, []string{"a", "b", "c"}, SplitList(" a, b,,c, ", ""))
+	require.Equal(t, []string{"/usr/bin", "/bin"}, SplitList("/usr/bin:/bin", ":"))
+	require.Empty(t, SplitList("", ","))
+}
+
+func TestCompactJSON(t *testing.T) {
+	s, err := CompactJSON(`{ "a": [1, 2],
+  "b": "c d" }`)
+	require.NoError(t, err)
+	require.Equal(t, `{"a":[1,2],"b":"c d"}`, s)
+
+	_, err = CompactJSON(`{"a":`)
+	require.ErrorContains(t, err, "must be JSON")
+}
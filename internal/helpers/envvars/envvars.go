@@ -0,0 +1,135 @@
+package envvars
+
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// Parse converts a list of "key=value" strings, as returned by os.Environ,
+// into a map. Only the first '=' separates the key from the value, so values
+// may themselves contain '='. On Windows, os.Environ may return entries for
+// per-drive working directories such as "=C:=C:\\", which are skipped.
+func Parse(environ []string) map[string]string {
+	env := make(map[string]string, len(environ))
+
+	for _, e := range environ {
+		key, value, ok := strings.Cut(e, "=")
+
+		if !ok || key == "" {
+			continue
+		}
+
+		env[key] = value
+	}
+
+	return env
+}
+
+// Pattern matches variable names. It is either a prefix of the name, or when
+// written between slashes, e.g. "/^AWS_(PROFILE|REGION)$/", a regular expression.
+type Pattern struct {
+	prefix string
+	re     *regexp.Regexp
+}
+
+// ParsePattern parses a pattern in the form described by Pattern.
+func ParsePattern(s string) (*Pattern, error) {
+	if len(s) >= 2 && strings.HasPrefix(s, "/") && strings.HasSuffix(s, "/") {
+		re, err := regexp.Compile(s[1 : len(s)-1])
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q: %w", s, err)
+		}
+
+		return &Pattern{re: re}, nil
+	}
+
+	if s == "" {
+		return nil, fmt.Errorf("pattern must not be empty")
+	}
+
+	return &Pattern{prefix: s}, nil
+}
+
+// ParsePatterns parses each of the given patterns.
+func ParsePatterns(s []string) ([]*Pattern, error) {
+	patterns := make([]*Pattern, 0, len(s))
+
+	for _, p := range s {
+		pattern, err := ParsePattern(p)
+
+		if err != nil {
+			return nil, err
+		}
+
+		patterns = append(patterns, pattern)
+	}
+
+	return patterns, nil
+}
+
+// Match reports whether the name matches the pattern. Prefixes are compared
+// case-insensitively on Windows, where variable names are not case sensitive.
+func (p *Pattern) Match(name string) bool {
+	if p.re != nil {
+		return p.re.MatchString(name)
+	}
+
+	if len(name) < len(p.prefix) {
+		return false
+	}
+
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(name[:len(p.prefix)], p.prefix)
+	}
+
+	return strings.HasPrefix(name, p.prefix)
+}
+
+// Filter returns the variables whose names match any of include and none of
+// exclude. If include is empty, all variables not excluded are returned.
+func Filter(env map[string]string, include, exclude []*Pattern) map[string]string {
+	filtered := make(map[string]string, len(env))
+
+	for k, v := range env {
+		if len(include) > 0 && !matchAny(include, k) {
+			continue
+		}
+
+		if matchAny(exclude, k) {
+			continue
+		}
+
+		filtered[k] = v
+	}
+
+	return filtered
+}
+
+// Select returns the variables whose names are in names. Names are compared
+// case-insensitively on Windows.
+func Select(env map[string]string, names []string) map[string]string {
+	selected := make(map[string]string, len(names))
+
+	for _, n := range names {
+		for k, v := range env {
+			if k == n || (runtime.GOOS == "windows" && strings.EqualFold(k, n)) {
+				selected[k] = v
+			}
+		}
+	}
+
+	return selected
+}
+
+func matchAny(patterns []*Pattern, name string) bool {
+	for _, p := range patterns {
+		if p.Match(name) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,82 @@
+package envvars
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	env := Parse([]string{
+		"PATH=/usr/bin:/bin",
+		"TOKEN=dGVzdA==",
+		"DSN=host=db user=app",
+		"EMPTY=",
+		"=C:=C:\\Windows",
+		"NOEQUALS",
+	})
+
+	require.Equal(t, map[string]string{
+		"PATH":  "/usr/bin:/bin",
+		"TOKEN": "dGVzdA==",
+		"DSN":   "host=db user=app",
+		"EMPTY": "",
+	}, env)
+}
+
+func TestParsePattern(t *testing.T) {
+	_, err := ParsePattern("")
+	require.Error(t, err)
+
+	_, err = ParsePattern("/[/")
+	require.ErrorContains(t, err, "invalid regular expression")
+
+	// A single slash is a prefix, not an empty regular expression
+	p, err := ParsePattern("/")
+	require.NoError(t, err)
+	require.True(t, p.Match("/x"))
+	require.False(t, p.Match("x"))
+}
+
+func TestFilter(t *testing.T) {
+	env := map[string]string{
+		"AWS_PROFILE":           "dev",
+		"AWS_REGION":            "eu-west-1",
+		"AWS_SECRET_ACCESS_KEY": "secret",
+		"TF_LOG":                "DEBUG",
+		"HOME":                  "/home/user",
+	}
+
+	mustParse := func(s ...string) []*Pattern {
+		p, err := ParsePatterns(s)
+		require.NoError(t, err)
+		return p
+	}
+
+	require.Equal(t, env, Filter(env, nil, nil))
+
+	require.Equal(t, map[string]string{
+		"AWS_PROFILE": "dev",
+		"AWS_REGION":  "eu-west-1",
+		"TF_LOG":      "DEBUG",
+	}, Filter(env, mustParse("AWS_", "TF_"), mustParse("/SECRET/")))
+
+	require.Equal(t, map[string]string{
+		"AWS_PROFILE": "dev",
+		"AWS_REGION":  "eu-west-1",
+	}, Filter(env, mustParse("/^AWS_(PROFILE|REGION)$/"), nil))
+
+	require.Equal(t, map[string]string{
+		"HOME": "/home/user",
+	}, Filter(env, nil, mustParse("AWS_", "TF_")))
+}
+
+func TestSelect(t *testing.T) {
+	env := map[string]string{
+		"AWS_PROFILE": "dev",
+		"HOME":        "/home/user",
+	}
+
+	require.Equal(t, map[string]string{"HOME": "/home/user"}, Select(env, []string{"HOME", "MISSING"}))
+	require.Empty(t, Select(env, nil))
+}
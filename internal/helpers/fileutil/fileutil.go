@@ -0,0 +1,174 @@
+package fileutil
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultLockTimeout is how long Lock waits for another process to release a file.
+	DefaultLockTimeout = 30 * time.Second
+
+	lockRetryInterval = 50 * time.Millisecond
+)
+
+var (
+	// Serialises access within this process, as terraform runs
+	// resource operations concurrently.
+	mu    sync.Mutex
+	locks = map[string]*sync.Mutex{}
+)
+
+// Lock takes an exclusive lock on path, both within this process and against
+// other processes using the same convention, by creating path + ".lock".
+// The returned function releases the lock.
+func Lock(path string, timeout time.Duration) (func(), error) {
+	path, err := filepath.Abs(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	mu.Lock()
+	l, ok := locks[path]
+
+	if !ok {
+		l = &sync.Mutex{}
+		locks[path] = l
+	}
+
+	mu.Unlock()
+	l.Lock()
+
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(timeout)
+
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+
+		if err == nil {
+			fmt.Fprintf(f, "%d\n", os.Getpid())
+			f.Close()
+
+			return func() {
+				os.Remove(lockPath)
+				l.Unlock()
+			}, nil
+		}
+
+		if !errors.Is(err, os.ErrExist) || time.Now().After(deadline) {
+			l.Unlock()
+
+			if errors.Is(err, os.ErrExist) {
+				return nil, fmt.Errorf("timed out waiting for lock %s. If no other process is using it, delete it", lockPath)
+			}
+
+			return nil, err
+		}
+
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// WriteAtomic replaces the content of path by writing to a temporary file in the
+// same directory and renaming it over path, so that readers never see a partially
+// written file. If path is a symbolic link, the file it points to is replaced.
+// The file is given mode perm.
+func WriteAtomic(path string, data []byte, perm os.FileMode) error {
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+
+	if err != nil {
+		return err
+	}
+
+	tmpPath := f.Name()
+
+	// Remove the temporary file if anything fails before the rename
+	committed := false
+
+	defer func() {
+		if !committed {
+			os.Remove(tmpPath)
+		}
+	}()
+
+	if _, err = f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err = f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	if err = os.Chmod(tmpPath, perm); err != nil {
+		return err
+	}
+
+	if err = os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+
+	committed = true
+	return nil
+}
+
+// WriteInPlace replaces the content of path by rewriting the existing file, creating
+// it with mode perm if it does not exist. Unlike WriteAtomic, the file keeps its inode,
+// so bind mounts such as /etc/hosts in a container, SELinux labels and ACLs are kept,
+// but readers may see a partially written file, so callers should hold its Lock.
+func WriteInPlace(path string, data []byte, perm os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, perm)
+
+	if err != nil {
+		return err
+	}
+
+	// Truncated after writing, so the file is never empty if the content grows
+	if _, err = f.WriteAt(data, 0); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err = f.Truncate(int64(len(data))); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err = f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// Mode returns the permission bits of path, or def if path does not exist.
+func Mode(path string, def os.FileMode) (os.FileMode, error) {
+	fi, err := os.Stat(path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return def, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return fi.Mode().Perm(), nil
+}
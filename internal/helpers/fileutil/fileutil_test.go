@@ -0,0 +1,133 @@
+package fileutil
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteAtomic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts")
+
+	require.NoError(t, WriteAtomic(path, []byte("one\n"), 0o600))
+	require.NoError(t, WriteAtomic(path, []byte("two\n"), 0o600))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "two\n", string(b))
+
+	// No temporary files left behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	if runtime.GOOS != "windows" {
+		mode, err := Mode(path, 0)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0o600), mode)
+	}
+}
+
+func TestWriteAtomicFollowsSymlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Symbolic links require elevation on Windows")
+	}
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target")
+	link := filepath.Join(dir, "link")
+	require.NoError(t, os.WriteFile(target, []byte("old"), 0o644))
+	require.NoError(t, os.Symlink(target, link))
+
+	require.NoError(t, WriteAtomic(link, []byte("new"), 0o644))
+
+	fi, err := os.Lstat(link)
+	require.NoError(t, err)
+	require.NotZero(t, fi.Mode()&os.ModeSymlink)
+
+	b, err := os.ReadFile(target)
+	require.NoError(t, err)
+	require.Equal(t, "new", string(b))
+}
+
+func TestWriteInPlace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts")
+
+	require.NoError(t, WriteInPlace(path, []byte("127.0.0.1 localhost\n10.0.0.1 longer.example\n"), 0o644))
+
+	before, err := os.Stat(path)
+	require.NoError(t, err)
+
+	// Shorter content is not left with the tail of the old
+	require.NoError(t, WriteInPlace(path, []byte("127.0.0.1 localhost\n"), 0o600))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1 localhost\n", string(b))
+
+	// The same file is rewritten, not replaced
+	after, err := os.Stat(path)
+	require.NoError(t, err)
+	require.True(t, os.SameFile(before, after))
+}
+
+func TestModeDefault(t *testing.T) {
+	mode, err := Mode(filepath.Join(t.TempDir(), "missing"), 0o640)
+
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o640), mode)
+}
+
+func TestLockSerialises(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts")
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	holders, maxHolders := 0, 0
+
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			unlock, err := Lock(path, 5*time.Second)
+			require.NoError(t, err)
+
+			mu.Lock()
+			holders++
+
+			if holders > maxHolders {
+				maxHolders = holders
+			}
+
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			holders--
+			mu.Unlock()
+
+			unlock()
+		}()
+	}
+
+	wg.Wait()
+	require.Equal(t, 1, maxHolders)
+
+	_, err := os.Stat(path + ".lock")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestLockTimesOutOnForeignLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts")
+	require.NoError(t, os.WriteFile(path+".lock", nil, 0o600))
+
+	_, err := Lock(path, 100*time.Millisecond)
+
+	require.ErrorContains(t, err, "timed out")
+}
@@ -0,0 +1,86 @@
+package hostname
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/uname"
+)
+
+// Resolver is the subset of net.Resolver used to find the FQDN.
+type Resolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+	LookupAddr(ctx context.Context, addr string) ([]string, error)
+}
+
+type Info struct {
+	Hostname  string
+	ShortName string
+	FQDN      string
+	Domain    string
+	Nodename  string
+}
+
+// Get returns the names of this machine. The FQDN is found in the same
+// way as hostname -f, i.e. by resolving the hostname to its addresses then
+// looking for a canonical name for one of those addresses. Both /etc/hosts
+// and DNS are consulted, according to the resolver's configuration.
+// If no fully qualified name can be found, FQDN is the hostname.
+func Get(ctx context.Context, resolver Resolver) (*Info, error) {
+	h, err := os.Hostname()
+
+	if err != nil {
+		return nil, err
+	}
+
+	info := &Info{
+		Hostname:  h,
+		ShortName: strings.SplitN(h, ".", 2)[0],
+		FQDN:      resolveFQDN(ctx, resolver, h),
+		Nodename:  h,
+	}
+
+	if _, domain, ok := strings.Cut(info.FQDN, "."); ok {
+		info.Domain = domain
+	}
+
+	// On Windows, uname is emulated and the node name is the hostname
+	if u, err := uname.Uname(); err == nil {
+		info.Nodename = u.Nodename
+	}
+
+	return info, nil
+}
+
+func resolveFQDN(ctx context.Context, resolver Resolver, h string) string {
+	if strings.Contains(h, ".") {
+		return h
+	}
+
+	addrs, err := resolver.LookupHost(ctx, h)
+
+	if err != nil {
+		return h
+	}
+
+	prefix := strings.ToLower(h) + "."
+
+	for _, addr := range addrs {
+		names, err := resolver.LookupAddr(ctx, addr)
+
+		if err != nil {
+			continue
+		}
+
+		for _, name := range names {
+			name = strings.TrimSuffix(name, ".")
+
+			if strings.HasPrefix(strings.ToLower(name), prefix) {
+				return name
+			}
+		}
+	}
+
+	return h
+}
@@ -0,0 +1,72 @@
+package hostname
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeResolver resolves from fixed maps.
+type fakeResolver struct {
+	hosts map[string][]string
+	addrs map[string][]string
+}
+
+func (r *fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if a, ok := r.hosts[host]; ok {
+		return a, nil
+	}
+
+	return nil, errors.New("no such host")
+}
+
+func (r *fakeResolver) LookupAddr(ctx context.Context, addr string) ([]string, error) {
+	if n, ok := r.addrs[addr]; ok {
+		return n, nil
+	}
+
+	return nil, errors.New("no such host")
+}
+
+func TestResolveFQDNFromReverseLookup(t *testing.T) {
+	r := &fakeResolver{
+		hosts: map[string][]string{"build01": {"::1", "127.0.1.1"}},
+		addrs: map[string][]string{
+			"::1":       {"localhost."},
+			"127.0.1.1": {"build01.lab.example.com.", "build01."},
+		},
+	}
+
+	require.Equal(t, "build01.lab.example.com", resolveFQDN(context.Background(), r, "build01"))
+}
+
+func TestResolveFQDNAlreadyQualified(t *testing.T) {
+	r := &fakeResolver{}
+
+	require.Equal(t, "build01.lab.example.com", resolveFQDN(context.Background(), r, "build01.lab.example.com"))
+}
+
+func TestResolveFQDNFallsBackToHostname(t *testing.T) {
+	r := &fakeResolver{
+		hosts: map[string][]string{"build01": {"10.0.0.5"}},
+		addrs: map[string][]string{"10.0.0.5": {"other.example.com."}},
+	}
+
+	require.Equal(t, "build01", resolveFQDN(context.Background(), r, "build01"))
+}
+
+func TestGet(t *testing.T) {
+	expected, err := os.Hostname()
+	require.NoError(t, err)
+
+	info, err := Get(context.Background(), &fakeResolver{})
+
+	require.NoError(t, err)
+	require.Equal(t, expected, info.Hostname)
+	require.True(t, strings.HasPrefix(info.Hostname, info.ShortName))
+	require.NotEmpty(t, info.Nodename)
+}
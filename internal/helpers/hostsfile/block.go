@@ -0,0 +1,139 @@
+package hostsfile
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Identifies blocks written by the localos_hosts_entry resource.
+const blockTag = "localos_hosts_entry"
+
+// BlockMarkers returns the comment lines that delimit the block of entries
+// managed under name.
+func BlockMarkers(name string) (begin, end string) {
+	return "# BEGIN " + blockTag + " " + name, "# END " + blockTag + " " + name
+}
+
+// ReadBlock returns the entries within the block named name in content.
+// found is false if there is no such block. An error is returned if the block
+// has no END marker, as the entries after it cannot be told apart from others.
+func ReadBlock(content []byte, name string) (entries []*Entry, found bool, err error) {
+	begin, end := BlockMarkers(name)
+	lines := splitLines(content)
+	entries = make([]*Entry, 0, 4)
+	inBlock := false
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == begin:
+			inBlock, found = true, true
+		case trimmed == end:
+			inBlock = false
+		case inBlock:
+			if e := parseLine(line); e != nil {
+				entries = append(entries, e)
+			}
+		}
+	}
+
+	if inBlock {
+		return nil, true, unterminated(name)
+	}
+
+	return entries, found, nil
+}
+
+// SetBlock returns content with the block named name replaced by entries.
+// If there is no such block it is appended at the end of the file.
+// If entries is nil the block is removed. All other content, including
+// the line ending convention, is preserved. If the block has no END marker,
+// an error is returned rather than remove the lines after it.
+func SetBlock(content []byte, name string, entries []*Entry) ([]byte, error) {
+	begin, end := BlockMarkers(name)
+	newline := "\n"
+
+	if bytes.Contains(content, []byte("\r\n")) {
+		newline = "\r\n"
+	}
+
+	var block []string
+
+	if entries != nil {
+		block = make([]string, 0, len(entries)+2)
+		block = append(block, begin)
+
+		for _, e := range entries {
+			block = append(block, e.String())
+		}
+
+		block = append(block, end)
+	}
+
+	lines := splitLines(content)
+	result := make([]string, 0, len(lines)+len(block))
+	inBlock, replaced := false, false
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == begin:
+			inBlock = true
+
+			if !replaced {
+				result = append(result, block...)
+				replaced = true
+			}
+		case inBlock:
+			if trimmed == end {
+				inBlock = false
+			}
+		default:
+			result = append(result, line)
+		}
+	}
+
+	if inBlock {
+		return nil, unterminated(name)
+	}
+
+	if !replaced {
+		result = append(result, block...)
+	}
+
+	if len(result) == 0 {
+		return []byte{}, nil
+	}
+
+	return []byte(strings.Join(result, newline) + newline), nil
+}
+
+func unterminated(name string) error {
+	_, end := BlockMarkers(name)
+	return fmt.Errorf("block %q has no %q line. Add it after the last entry of the block", name, end)
+}
+
+// String formats the entry as a hosts file line.
+func (e *Entry) String() string {
+	line := e.Address + "\t" + strings.Join(e.Names(), " ")
+
+	if e.Comment != "" {
+		line += " # " + e.Comment
+	}
+
+	return line
+}
+
+func splitLines(content []byte) []string {
+	s := strings.ReplaceAll(string(content), "\r\n", "\n")
+	s = strings.TrimSuffix(s, "\n")
+
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(s, "\n")
+}
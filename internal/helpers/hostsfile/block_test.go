@@ -0,0 +1,104 @@
+package hostsfile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const unmanaged = `127.0.0.1 localhost
+# keep this comment
+::1 localhost ip6-localhost
+`
+
+var ingressEntries = []*Entry{
+	{Address: "192.168.49.2", CanonicalName: "app.dev.local", Aliases: []string{"api.dev.local"}},
+}
+
+func setBlock(t *testing.T, content []byte, name string, entries []*Entry) []byte {
+	t.Helper()
+
+	content, err := SetBlock(content, name, entries)
+	require.NoError(t, err)
+
+	return content
+}
+
+func TestSetBlockAppends(t *testing.T) {
+	content := setBlock(t, []byte(unmanaged), "minikube", ingressEntries)
+
+	require.Equal(t, unmanaged+
+		"# BEGIN localos_hosts_entry minikube\n"+
+		"192.168.49.2\tapp.dev.local api.dev.local\n"+
+		"# END localos_hosts_entry minikube\n", string(content))
+
+	entries, found, err := ReadBlock(content, "minikube")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, ingressEntries, entries)
+
+	_, found, err = ReadBlock(content, "other")
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func TestSetBlockReplacesInPlace(t *testing.T) {
+	content := setBlock(t, []byte(unmanaged), "minikube", ingressEntries)
+	content = append(content, []byte("10.0.0.1 after\n")...)
+
+	content = setBlock(t, content, "minikube", []*Entry{{Address: "192.168.49.3", CanonicalName: "app.dev.local"}})
+
+	require.Equal(t, unmanaged+
+		"# BEGIN localos_hosts_entry minikube\n"+
+		"192.168.49.3\tapp.dev.local\n"+
+		"# END localos_hosts_entry minikube\n"+
+		"10.0.0.1 after\n", string(content))
+}
+
+func TestSetBlockRemoves(t *testing.T) {
+	content := setBlock(t, []byte(unmanaged), "minikube", ingressEntries)
+	content = setBlock(t, content, "minikube", nil)
+
+	require.Equal(t, unmanaged, string(content))
+}
+
+func TestSetBlockPreservesCRLF(t *testing.T) {
+	content := setBlock(t, []byte("127.0.0.1 localhost\r\n"), "kind", ingressEntries)
+
+	require.Equal(t, "127.0.0.1 localhost\r\n"+
+		"# BEGIN localos_hosts_entry kind\r\n"+
+		"192.168.49.2\tapp.dev.local api.dev.local\r\n"+
+		"# END localos_hosts_entry kind\r\n", string(content))
+}
+
+func TestReadBlockEmpty(t *testing.T) {
+	content := setBlock(t, nil, "empty", []*Entry{})
+
+	entries, found, err := ReadBlock(content, "empty")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Empty(t, entries)
+}
+
+func TestBlockUnterminated(t *testing.T) {
+	content := []byte(unmanaged +
+		"# BEGIN localos_hosts_entry minikube\n" +
+		"192.168.49.2\tapp.dev.local\n" +
+		"10.0.0.1 other.example\n")
+
+	_, found, err := ReadBlock(content, "minikube")
+	require.True(t, found)
+	require.ErrorContains(t, err, "# END localos_hosts_entry minikube")
+
+	// The lines after the marker are not dropped
+	_, err = SetBlock(content, "minikube", ingressEntries)
+	require.Error(t, err)
+
+	_, err = SetBlock(content, "minikube", nil)
+	require.Error(t, err)
+
+	// Other blocks are unaffected
+	_, found, err = ReadBlock(content, "other")
+	require.NoError(t, err)
+	require.False(t, found)
+}
@@ -0,0 +1,127 @@
+package hostsfile
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+type Entry struct {
+	Address       string
+	CanonicalName string
+	Aliases       []string
+	Comment       string
+}
+
+// DefaultPath returns the location of the hosts file for the current operating system.
+func DefaultPath() string {
+	if runtime.GOOS == "windows" {
+		root := os.Getenv("SystemRoot")
+
+		if root == "" {
+			root = `C:\Windows`
+		}
+
+		return filepath.Join(root, "System32", "drivers", "etc", "hosts")
+	}
+
+	return "/etc/hosts"
+}
+
+// Read parses the hosts file at path.
+func Read(path string) ([]*Entry, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return Parse(f)
+}
+
+// Parse parses hosts file content. Lines that are entirely comment, and
+// lines that do not start with a valid IP address, are ignored.
+// A comment at the end of an entry is returned with the entry.
+func Parse(r io.Reader) ([]*Entry, error) {
+	result := make([]*Entry, 0, 16)
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		if e := parseLine(scanner.Text()); e != nil {
+			result = append(result, e)
+		}
+	}
+
+	return result, scanner.Err()
+}
+
+func parseLine(line string) *Entry {
+	var comment string
+
+	if i := strings.IndexByte(line, '#'); i >= 0 {
+		comment = strings.TrimSpace(line[i+1:])
+		line = line[:i]
+	}
+
+	fields := strings.Fields(line)
+
+	if len(fields) < 2 || net.ParseIP(fields[0]) == nil {
+		return nil
+	}
+
+	return &Entry{
+		Address:       fields[0],
+		CanonicalName: fields[1],
+		Aliases:       fields[2:],
+		Comment:       comment,
+	}
+}
+
+// Names returns the canonical name followed by all aliases.
+func (e *Entry) Names() []string {
+	return append([]string{e.CanonicalName}, e.Aliases...)
+}
+
+// AddressesByName maps each name to the addresses it resolves to via the hosts file,
+// in file order. Names are lower cased as name lookup is case insensitive.
+func AddressesByName(entries []*Entry) map[string][]string {
+	result := make(map[string][]string)
+
+	for _, e := range entries {
+		for _, name := range e.Names() {
+			name = strings.ToLower(name)
+			result[name] = appendUnique(result[name], e.Address)
+		}
+	}
+
+	return result
+}
+
+// NamesByAddress maps each address to the names given for it, in file order.
+func NamesByAddress(entries []*Entry) map[string][]string {
+	result := make(map[string][]string)
+
+	for _, e := range entries {
+		for _, name := range e.Names() {
+			result[e.Address] = appendUnique(result[e.Address], name)
+		}
+	}
+
+	return result
+}
+
+func appendUnique(s []string, v string) []string {
+	for _, existing := range s {
+		if existing == v {
+			return s
+		}
+	}
+
+	return append(s, v)
+}
@@ -0,0 +1,57 @@
+package hostsfile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const hosts = `# Static table lookup for hostnames.
+127.0.0.1	localhost
+::1		localhost ip6-localhost ip6-loopback
+127.0.1.1	devbox.lab.example.com devbox
+
+# Local cluster ingress
+192.168.49.2 app.dev.local api.dev.local # minikube
+192.168.49.2 App.dev.local
+not-an-ip    broken.dev.local
+`
+
+func TestParse(t *testing.T) {
+	entries, err := Parse(strings.NewReader(hosts))
+
+	require.NoError(t, err)
+	require.Len(t, entries, 5)
+
+	require.Equal(t, "::1", entries[1].Address)
+	require.Equal(t, "localhost", entries[1].CanonicalName)
+	require.Equal(t, []string{"ip6-localhost", "ip6-loopback"}, entries[1].Aliases)
+	require.Equal(t, "", entries[1].Comment)
+
+	require.Equal(t, "192.168.49.2", entries[3].Address)
+	require.Equal(t, "app.dev.local", entries[3].CanonicalName)
+	require.Equal(t, []string{"api.dev.local"}, entries[3].Aliases)
+	require.Equal(t, "minikube", entries[3].Comment)
+}
+
+func TestAddressesByName(t *testing.T) {
+	entries, err := Parse(strings.NewReader(hosts))
+	require.NoError(t, err)
+
+	byName := AddressesByName(entries)
+
+	require.Equal(t, []string{"127.0.0.1", "::1"}, byName["localhost"])
+	require.Equal(t, []string{"192.168.49.2"}, byName["app.dev.local"])
+	require.NotContains(t, byName, "broken.dev.local")
+}
+
+func TestNamesByAddress(t *testing.T) {
+	entries, err := Parse(strings.NewReader(hosts))
+	require.NoError(t, err)
+
+	byAddress := NamesByAddress(entries)
+
+	require.Equal(t, []string{"devbox.lab.example.com", "devbox"}, byAddress["127.0.1.1"])
+	require.Equal(t, []string{"app.dev.local", "api.dev.local", "App.dev.local"}, byAddress["192.168.49.2"])
+}
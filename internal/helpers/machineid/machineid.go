@@ -0,0 +1,276 @@
+package machineid
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/fileutil"
+)
+
+// ProviderAppID is the application ID used when none is given, so that IDs
+// derived by the provider cannot be correlated with those of other applications.
+const ProviderAppID = "c0be6e38b0ad4ae88a3df34a33490fce"
+
+// Sources of the machine ID, as reported in Identity.Source.
+const (
+	SourceSystemd   = "systemd"
+	SourceDbus      = "dbus"
+	SourceWindows   = "windows"
+	SourceMacOS     = "macos"
+	SourceGenerated = "generated"
+)
+
+// Paths are the files the identity is read from.
+type Paths struct {
+	// MachineID is systemd's /etc/machine-id
+	MachineID string
+
+	// DbusMachineID is read if there is no systemd machine ID
+	DbusMachineID string
+
+	// BootID changes on every boot
+	BootID string
+
+	// Platform reads the machine ID of the operating system if neither file holds
+	// one: MachineGuid in the registry on Windows, and IOPlatformUUID on macOS
+	Platform bool
+
+	// Fallback holds an ID generated by the provider where there is no machine
+	// ID. If empty, no ID is generated.
+	Fallback string
+}
+
+// Identity holds the IDs of the machine as 32 lower case hex digits, the format of /etc/machine-id.
+type Identity struct {
+	// MachineID is the raw machine ID, which should not be disclosed, see AppSpecific
+	MachineID string
+
+	// Source is where MachineID was read from, one of the Source constants
+	Source string
+
+	// Path is the file MachineID was read from, or for a platform ID, the
+	// registry value or IOKit property
+	Path string
+
+	// BootID is "" where not known
+	BootID string
+}
+
+// DefaultPaths gets the paths used on this system. The fallback is under the
+// state directory of the user, e.g. ~/.local/state on Linux.
+func DefaultPaths() Paths {
+	p := Paths{
+		MachineID:     "/etc/machine-id",
+		DbusMachineID: "/var/lib/dbus/machine-id",
+		BootID:        "/proc/sys/kernel/random/boot_id",
+		Platform:      true,
+	}
+
+	if dir, err := stateDir(); err == nil {
+		p.Fallback = filepath.Join(dir, "terraform-provider-localos", "machine-id")
+	}
+
+	return p
+}
+
+// Read gets the identity of the machine. If neither machine ID file holds a
+// valid ID, nor is there a platform ID, a random one is generated and saved in
+// p.Fallback, so that it is the same on later runs.
+func Read(p Paths) (*Identity, error) {
+	id := &Identity{}
+
+	if b, err := os.ReadFile(p.BootID); err == nil {
+		id.BootID, _ = parse(string(b))
+	}
+
+	for _, f := range []struct{ path, source string }{{p.MachineID, SourceSystemd}, {p.DbusMachineID, SourceDbus}} {
+		if f.path == "" {
+			continue
+		}
+
+		if b, err := os.ReadFile(f.path); err == nil {
+			if mid, ok := parse(string(b)); ok {
+				id.MachineID, id.Source, id.Path = mid, f.source, f.path
+				return id, nil
+			}
+		}
+	}
+
+	if p.Platform {
+		if platform, err := platformID(); err == nil {
+			platform.BootID = id.BootID
+			return platform, nil
+		}
+	}
+
+	if p.Fallback == "" {
+		return nil, errors.New("no machine ID found")
+	}
+
+	mid, err := fallback(p.Fallback)
+
+	if err != nil {
+		return nil, fmt.Errorf("no machine ID found, and unable to generate one: %w", err)
+	}
+
+	id.MachineID, id.Source, id.Path = mid, SourceGenerated, p.Fallback
+
+	return id, nil
+}
+
+// AppSpecific derives an ID for an application from a machine or boot ID in the
+// same way as systemd's sd_id128_get_machine_app_specific: the HMAC-SHA256 of the
+// application ID keyed by the machine ID, made into a version 4 UUID. Different
+// applications then get unrelated IDs, and the machine ID cannot be recovered.
+//
+// If appID is a 128-bit ID, as 32 hex digits or a UUID, the result is the same as
+// "systemd-id128 machine-id --app-specific=appID". Any other string is first
+// made into an ID by taking the leading 128 bits of its SHA-256.
+func AppSpecific(id, appID string) (string, error) {
+	key, err := hex.DecodeString(id)
+
+	if err != nil || len(key) != 16 {
+		return "", fmt.Errorf("invalid ID %q", id)
+	}
+
+	app, ok := parse(appID)
+
+	if !ok {
+		sum := sha256.Sum256([]byte(appID))
+		app = hex.EncodeToString(sum[:16])
+	}
+
+	msg, _ := hex.DecodeString(app)
+	mac := hmac.New(sha256.New, key)
+	mac.Write(msg)
+
+	return hex.EncodeToString(uuidV4(mac.Sum(nil)[:16])), nil
+}
+
+// platformIdentity makes the identity for a machine ID read from the operating system.
+func platformIdentity(value, source, path string) (*Identity, error) {
+	mid, ok := parse(value)
+
+	if !ok {
+		return nil, fmt.Errorf("invalid machine ID %q in %s", value, path)
+	}
+
+	return &Identity{MachineID: mid, Source: source, Path: path}, nil
+}
+
+// parseIoreg gets the IOPlatformUUID from the output of
+// "ioreg -rd1 -c IOPlatformExpertDevice", in which it is a line such as
+//
+//	"IOPlatformUUID" = "0A6F1E2C-3B4D-5E6F-7A8B-9C0D1E2F3A4B"
+func parseIoreg(out string) (string, error) {
+	for _, line := range strings.Split(out, "\n") {
+		key, value, ok := strings.Cut(line, "=")
+
+		if ok && strings.TrimSpace(key) == `"IOPlatformUUID"` {
+			return strings.Trim(strings.TrimSpace(value), `"`), nil
+		}
+	}
+
+	return "", errors.New("IOPlatformUUID not found")
+}
+
+// parse checks an ID in the format of machine-id or boot_id, and returns it as
+// 32 lower case hex digits. IDs of all zeros, as in an uninitialised
+// /etc/machine-id, are rejected.
+func parse(s string) (string, bool) {
+	s = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), "-", ""))
+
+	if b, err := hex.DecodeString(s); err != nil || len(b) != 16 || s == strings.Repeat("0", 32) {
+		return "", false
+	}
+
+	return s, true
+}
+
+// uuidV4 sets the version and variant bits of a random UUID.
+func uuidV4(b []byte) []byte {
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return b
+}
+
+// fallback reads the generated ID at path, creating it if it does not exist.
+// The lock prevents concurrent provider processes from generating different IDs.
+func fallback(path string) (string, error) {
+	if b, err := os.ReadFile(path); err == nil {
+		if id, ok := parse(string(b)); ok {
+			return id, nil
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", err
+	}
+
+	unlock, err := fileutil.Lock(path, fileutil.DefaultLockTimeout)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer unlock()
+
+	// Another process may have created it while waiting for the lock
+	if b, err := os.ReadFile(path); err == nil {
+		if id, ok := parse(string(b)); ok {
+			return id, nil
+		}
+	}
+
+	b := make([]byte, 16)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	id := hex.EncodeToString(uuidV4(b))
+
+	if err := fileutil.WriteAtomic(path, []byte(id+"\n"), 0o600); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// stateDir gets the directory for persistent application state of the user:
+// $XDG_STATE_HOME or ~/.local/state, or on Windows %LOCALAPPDATA%, and on macOS
+// ~/Library/Application Support.
+func stateDir() (string, error) {
+	switch runtime.GOOS {
+	case "windows":
+		if dir := os.Getenv("LOCALAPPDATA"); dir != "" {
+			return dir, nil
+		}
+
+		return os.UserConfigDir()
+
+	case "darwin", "ios":
+		return os.UserConfigDir()
+	}
+
+	if dir := os.Getenv("XDG_STATE_HOME"); filepath.IsAbs(dir) {
+		return dir, nil
+	}
+
+	home, err := os.UserHomeDir()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".local", "state"), nil
+}
@@ -0,0 +1,26 @@
+//go:build darwin
+
+package machineid
+
+import (
+	"os/exec"
+)
+
+const ioregPath = "/usr/sbin/ioreg"
+
+// platformID reads the IOPlatformUUID of the machine, the hardware UUID shown in System Information.
+func platformID() (*Identity, error) {
+	out, err := exec.Command(ioregPath, "-rd1", "-c", "IOPlatformExpertDevice").Output()
+
+	if err != nil {
+		return nil, err
+	}
+
+	uuid, err := parseIoreg(string(out))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return platformIdentity(uuid, SourceMacOS, "IOPlatformExpertDevice/IOPlatformUUID")
+}
@@ -0,0 +1,12 @@
+//go:build !windows && !darwin
+
+package machineid
+
+import (
+	"errors"
+)
+
+// platformID is not needed where the machine ID is read from files.
+func platformID() (*Identity, error) {
+	return nil, errors.New("no platform machine ID on this operating system")
+}
@@ -0,0 +1,167 @@
+package machineid
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	machineID = "fed6b2924c424cf1b9a322f606b4de6d"
+	bootID    = "f04a465f-3fc7-4323-a4bd-041527c8a484"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func paths(t *testing.T) Paths {
+	dir := t.TempDir()
+
+	return Paths{
+		MachineID:     filepath.Join(dir, "etc", "machine-id"),
+		DbusMachineID: filepath.Join(dir, "var", "lib", "dbus", "machine-id"),
+		BootID:        filepath.Join(dir, "proc", "boot_id"),
+		Fallback:      filepath.Join(dir, "state", "terraform-provider-localos", "machine-id"),
+	}
+}
+
+func TestReadSystemd(t *testing.T) {
+	p := paths(t)
+	writeFile(t, p.MachineID, machineID+"\n")
+	writeFile(t, p.DbusMachineID, "0123456789abcdef0123456789abcdef\n")
+	writeFile(t, p.BootID, bootID+"\n")
+
+	id, err := Read(p)
+	require.NoError(t, err)
+	require.Equal(t, &Identity{
+		MachineID: machineID,
+		Source:    SourceSystemd,
+		Path:      p.MachineID,
+		BootID:    "f04a465f3fc74323a4bd041527c8a484",
+	}, id)
+}
+
+func TestReadDbus(t *testing.T) {
+	p := paths(t)
+
+	// Empty until the first boot completes
+	writeFile(t, p.MachineID, "uninitialized\n")
+	writeFile(t, p.DbusMachineID, machineID+"\n")
+
+	id, err := Read(p)
+	require.NoError(t, err)
+	require.Equal(t, machineID, id.MachineID)
+	require.Equal(t, SourceDbus, id.Source)
+	require.Empty(t, id.BootID)
+}
+
+func TestReadGenerated(t *testing.T) {
+	p := paths(t)
+	writeFile(t, p.MachineID, "00000000000000000000000000000000\n")
+
+	id, err := Read(p)
+	require.NoError(t, err)
+	require.Equal(t, SourceGenerated, id.Source)
+	require.Equal(t, p.Fallback, id.Path)
+	require.Len(t, id.MachineID, 32)
+	require.Equal(t, byte('4'), id.MachineID[12])
+
+	b, err := os.ReadFile(p.Fallback)
+	require.NoError(t, err)
+	require.Equal(t, id.MachineID+"\n", string(b))
+
+	// The same ID is returned on later reads
+	again, err := Read(p)
+	require.NoError(t, err)
+	require.Equal(t, id.MachineID, again.MachineID)
+}
+
+func TestReadGeneratedConcurrently(t *testing.T) {
+	p := paths(t)
+	ids := make([]string, 8)
+	errs := make([]error, len(ids))
+
+	var wg sync.WaitGroup
+
+	for i := range ids {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			if id, err := Read(p); err != nil {
+				errs[i] = err
+			} else {
+				ids[i] = id.MachineID
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i, id := range ids {
+		require.NoError(t, errs[i])
+		require.Equal(t, ids[0], id)
+	}
+}
+
+func TestParseIoreg(t *testing.T) {
+	out := `+-o MacBookPro18,3  <class IOPlatformExpertDevice, id 0x100000235, registered, matched, active, busy 0 (4 ms), retain 34>
+    {
+      "IOPlatformSerialNumber" = "C02ABCDEFGH1"
+      "IOPlatformUUID" = "FED6B292-4C42-4CF1-B9A3-22F606B4DE6D"
+      "model" = <"MacBookPro18,3">
+    }
+`
+
+	uuid, err := parseIoreg(out)
+	require.NoError(t, err)
+
+	id, err := platformIdentity(uuid, SourceMacOS, "IOPlatformExpertDevice/IOPlatformUUID")
+	require.NoError(t, err)
+	require.Equal(t, machineID, id.MachineID)
+
+	_, err = parseIoreg("")
+	require.Error(t, err)
+
+	_, err = platformIdentity("", SourceWindows, "MachineGuid")
+	require.Error(t, err)
+}
+
+func TestReadNoFallback(t *testing.T) {
+	p := paths(t)
+	p.Fallback = ""
+
+	_, err := Read(p)
+	require.Error(t, err)
+}
+
+func TestAppSpecific(t *testing.T) {
+	// From "systemd-id128 machine-id --app-specific=c0be6e38b0ad4ae88a3df34a33490fce"
+	// on a machine with this ID
+	id, err := AppSpecific(machineID, ProviderAppID)
+	require.NoError(t, err)
+	require.Equal(t, "2d81b81f7d91423b92982fe342109fed", id)
+
+	// The application ID may be a UUID
+	id, err = AppSpecific(machineID, "C0BE6E38-B0AD-4AE8-8A3D-F34A33490FCE")
+	require.NoError(t, err)
+	require.Equal(t, "2d81b81f7d91423b92982fe342109fed", id)
+
+	// Other strings give different IDs
+	a, err := AppSpecific(machineID, "inventory")
+	require.NoError(t, err)
+	b, err := AppSpecific(machineID, "billing")
+	require.NoError(t, err)
+	require.Len(t, a, 32)
+	require.NotEqual(t, a, b)
+	require.NotEqual(t, id, a)
+
+	_, err = AppSpecific("not an id", ProviderAppID)
+	require.Error(t, err)
+}
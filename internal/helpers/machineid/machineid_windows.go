@@ -0,0 +1,29 @@
+//go:build windows
+
+package machineid
+
+import (
+	"golang.org/x/sys/windows/registry"
+)
+
+const machineGUIDPath = `HKLM\SOFTWARE\Microsoft\Cryptography\MachineGuid`
+
+// platformID reads the MachineGuid that Windows generates on installation. The 64-bit
+// view of the registry is read, as 32-bit processes would otherwise see a different key.
+func platformID() (*Identity, error) {
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, `SOFTWARE\Microsoft\Cryptography`, registry.QUERY_VALUE|registry.WOW64_64KEY)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer k.Close()
+
+	guid, _, err := k.GetStringValue("MachineGuid")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return platformIdentity(guid, SourceWindows, machineGUIDPath)
+}
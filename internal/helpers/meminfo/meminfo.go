@@ -0,0 +1,145 @@
+package meminfo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ErrNotSupported is returned by Total on systems where it is not implemented.
+var ErrNotSupported = errors.New("reading memory size is not supported on this operating system")
+
+// DefaultProcRoot is where procfs is mounted.
+const DefaultProcRoot = "/proc"
+
+// Info holds the memory statistics of /proc/meminfo. All sizes are in bytes.
+type Info struct {
+	Total     uint64
+	Free      uint64
+	Available uint64
+	Buffers   uint64
+
+	// Cached is the page cache and reclaimable slab, as shown by free(1)
+	Cached uint64
+
+	SwapTotal  uint64
+	SwapFree   uint64
+	SwapCached uint64
+
+	// Huge page counts, and the size of each huge page
+	HugePagesTotal    uint64
+	HugePagesFree     uint64
+	HugePagesReserved uint64
+	HugePagesSurplus  uint64
+	HugePageSize      uint64
+
+	// Hugetlb is the memory used by huge pages of all sizes
+	Hugetlb uint64
+
+	// Fields holds every field of the file. Sizes are converted to bytes; counts are as they are.
+	Fields map[string]uint64
+}
+
+// Used is the memory in use by processes and the kernel, calculated as by free(1).
+func (i *Info) Used() uint64 {
+	reclaimable := i.Free + i.Buffers + i.Cached
+
+	if reclaimable > i.Total {
+		return 0
+	}
+
+	return i.Total - reclaimable
+}
+
+// SwapUsed is the swap space in use.
+func (i *Info) SwapUsed() uint64 {
+	if i.SwapFree > i.SwapTotal {
+		return 0
+	}
+
+	return i.SwapTotal - i.SwapFree
+}
+
+// Read reads meminfo from procfs mounted at procRoot.
+func Read(procRoot string) (*Info, error) {
+	f, err := os.Open(filepath.Join(procRoot, "meminfo"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return Parse(f)
+}
+
+// Parse reads the content of /proc/meminfo.
+func Parse(r io.Reader) (*Info, error) {
+	fields := make(map[string]uint64)
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+
+		if !ok {
+			continue
+		}
+
+		parts := strings.Fields(value)
+
+		if len(parts) == 0 {
+			continue
+		}
+
+		n, err := strconv.ParseUint(parts[0], 10, 64)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of %s: %q", key, value)
+		}
+
+		// The only unit used is kB, which is actually KiB
+		if len(parts) > 1 && parts[1] == "kB" {
+			n *= 1024
+		}
+
+		fields[strings.TrimSpace(key)] = n
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if _, ok := fields["MemTotal"]; !ok {
+		return nil, errors.New("MemTotal not found in meminfo")
+	}
+
+	info := &Info{
+		Total:             fields["MemTotal"],
+		Free:              fields["MemFree"],
+		Available:         fields["MemAvailable"],
+		Buffers:           fields["Buffers"],
+		Cached:            fields["Cached"] + fields["SReclaimable"],
+		SwapTotal:         fields["SwapTotal"],
+		SwapFree:          fields["SwapFree"],
+		SwapCached:        fields["SwapCached"],
+		HugePagesTotal:    fields["HugePages_Total"],
+		HugePagesFree:     fields["HugePages_Free"],
+		HugePagesReserved: fields["HugePages_Rsvd"],
+		HugePagesSurplus:  fields["HugePages_Surp"],
+		HugePageSize:      fields["Hugepagesize"],
+		Hugetlb:           fields["Hugetlb"],
+		Fields:            fields,
+	}
+
+	// MemAvailable was added in Linux 3.14. Before that, free memory plus caches was the usual estimate.
+	if _, ok := fields["MemAvailable"]; !ok {
+		info.Available = info.Free + info.Buffers + info.Cached
+	}
+
+	return info, nil
+}
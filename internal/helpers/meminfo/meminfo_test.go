@@ -0,0 +1,85 @@
+package meminfo
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const meminfo = `MemTotal:        6147400 kB
+MemFree:         3388932 kB
+MemAvailable:    5550420 kB
+Buffers:          103604 kB
+Cached:          2218644 kB
+SwapCached:          128 kB
+SwapTotal:       2097148 kB
+SwapFree:        2000000 kB
+SReclaimable:     123132 kB
+HugePages_Total:      64
+HugePages_Free:       60
+HugePages_Rsvd:        2
+HugePages_Surp:        1
+Hugepagesize:       2048 kB
+Hugetlb:          131072 kB
+`
+
+func TestParse(t *testing.T) {
+	info, err := Parse(strings.NewReader(meminfo))
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(6147400*1024), info.Total)
+	require.Equal(t, uint64(3388932*1024), info.Free)
+	require.Equal(t, uint64(5550420*1024), info.Available)
+	require.Equal(t, uint64(103604*1024), info.Buffers)
+	require.Equal(t, uint64((2218644+123132)*1024), info.Cached)
+	require.Equal(t, uint64((6147400-3388932-103604-2218644-123132)*1024), info.Used())
+	require.Equal(t, uint64(2097148*1024), info.SwapTotal)
+	require.Equal(t, uint64(97148*1024), info.SwapUsed())
+	require.Equal(t, uint64(128*1024), info.SwapCached)
+	require.Equal(t, uint64(64), info.HugePagesTotal)
+	require.Equal(t, uint64(60), info.HugePagesFree)
+	require.Equal(t, uint64(2), info.HugePagesReserved)
+	require.Equal(t, uint64(1), info.HugePagesSurplus)
+	require.Equal(t, uint64(2*1024*1024), info.HugePageSize)
+	require.Equal(t, uint64(128*1024*1024), info.Hugetlb)
+	require.Equal(t, uint64(64), info.Fields["HugePages_Total"])
+}
+
+func TestParseOldKernel(t *testing.T) {
+	info, err := Parse(strings.NewReader("MemTotal: 1000 kB\nMemFree: 100 kB\nBuffers: 10 kB\nCached: 200 kB\n"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(310*1024), info.Available)
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := Parse(strings.NewReader("MemFree: 100 kB\n"))
+	require.ErrorContains(t, err, "MemTotal")
+
+	_, err = Parse(strings.NewReader("MemTotal: lots kB\n"))
+	require.ErrorContains(t, err, "invalid value")
+}
+
+func TestRead(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "meminfo"), []byte(meminfo), 0o644))
+
+	info, err := Read(dir)
+	require.NoError(t, err)
+	require.Equal(t, uint64(6147400*1024), info.Total)
+}
+
+func TestTotal(t *testing.T) {
+	total, err := Total()
+
+	if runtime.GOOS != "darwin" {
+		require.ErrorIs(t, err, ErrNotSupported)
+		return
+	}
+
+	require.NoError(t, err)
+	require.NotZero(t, total)
+}
@@ -0,0 +1,10 @@
+//go:build darwin
+
+package meminfo
+
+import "golang.org/x/sys/unix"
+
+// Total returns the size of physical memory in bytes.
+func Total() (uint64, error) {
+	return unix.SysctlUint64("hw.memsize")
+}
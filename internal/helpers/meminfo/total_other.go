@@ -0,0 +1,8 @@
+//go:build !darwin
+
+package meminfo
+
+// Total returns the size of physical memory in bytes. On Linux, use Read instead.
+func Total() (uint64, error) {
+	return 0, ErrNotSupported
+}
@@ -0,0 +1,146 @@
+package mounts
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultProcRoot is where procfs is mounted.
+const DefaultProcRoot = "/proc"
+
+// ReadMountInfo reads the mounts visible to this process from procfs mounted at procRoot.
+func ReadMountInfo(procRoot string) ([]Mount, error) {
+	f, err := os.Open(filepath.Join(procRoot, "self", "mountinfo"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return ParseMountInfo(f)
+}
+
+// ParseMountInfo reads the content of /proc/<pid>/mountinfo, whose lines are
+//
+//	36 35 98:0 /mnt1 /mnt2 rw,noatime master:1 - ext3 /dev/root rw,errors=continue
+//
+// i.e. mount ID, parent ID, device number, root, mount point, mount options,
+// optional fields terminated by "-", filesystem type, source and superblock options.
+func ParseMountInfo(r io.Reader) ([]Mount, error) {
+	var mounts []Mount
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		sep := -1
+
+		for i := 6; i < len(fields); i++ {
+			if fields[i] == "-" {
+				sep = i
+				break
+			}
+		}
+
+		if sep < 0 || len(fields) < sep+3 {
+			return nil, fmt.Errorf("line %d: invalid mountinfo entry", line)
+		}
+
+		options := strings.Split(fields[5], ",")
+
+		if len(fields) > sep+3 {
+			// Add the superblock options that are not already set for the mount, e.g. errors=continue
+			for _, o := range strings.Split(fields[sep+3], ",") {
+				if !contains(options, o) && o != "ro" && o != "rw" {
+					options = append(options, o)
+				}
+			}
+		}
+
+		mounts = append(mounts, Mount{
+			MountPoint: unescape(fields[4]),
+			Device:     unescape(fields[sep+2]),
+			FSType:     unescape(fields[sep+1]),
+			Options:    options,
+			Root:       unescape(fields[3]),
+		})
+	}
+
+	return mounts, scanner.Err()
+}
+
+// FindMount returns the mount that contains path, which must be absolute and
+// have no symbolic links. Where filesystems are mounted over one another, the
+// last mounted is returned, as that is the one that is visible.
+func FindMount(mounts []Mount, path string) (*Mount, bool) {
+	var found *Mount
+
+	for i := range mounts {
+		m := &mounts[i]
+
+		if !within(path, m.MountPoint) {
+			continue
+		}
+
+		if found == nil || len(m.MountPoint) >= len(found.MountPoint) {
+			found = m
+		}
+	}
+
+	return found, found != nil
+}
+
+// within reports whether path is dir or is below it.
+func within(path, dir string) bool {
+	if dir == "/" || path == dir {
+		return true
+	}
+
+	return strings.HasPrefix(path, strings.TrimSuffix(dir, "/")+"/")
+}
+
+// unescape replaces the octal escapes used for space, tab, newline and backslash.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if n, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(n))
+				i += 3
+				continue
+			}
+		}
+
+		b.WriteByte(s[i])
+	}
+
+	return b.String()
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
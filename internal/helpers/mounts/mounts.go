@@ -0,0 +1,77 @@
+package mounts
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// ErrNotSupported is returned on systems where mounts cannot be read.
+var ErrNotSupported = errors.New("reading mounts is not supported on this operating system")
+
+// Mount is a mounted filesystem.
+type Mount struct {
+	// MountPoint is where the filesystem is mounted, e.g. "/home" or "C:\"
+	MountPoint string
+
+	// Device is the source of the mount, e.g. "/dev/sda1", "server:/export" or "tmpfs"
+	Device string
+
+	// FSType is the type of filesystem, e.g. "ext4", "apfs" or "NTFS"
+	FSType string
+
+	// Options are the mount options, e.g. "rw", "noatime"
+	Options []string
+
+	// Root is the directory of the filesystem that is mounted, which is not "/" for bind mounts. Only set on Linux.
+	Root string
+}
+
+// Usage is the space and inodes of a filesystem.
+type Usage struct {
+	TotalBytes uint64
+	FreeBytes  uint64
+
+	// AvailableBytes is the space available to unprivileged users, which excludes space reserved for root
+	AvailableBytes uint64
+
+	// Inode counts are 0 where the filesystem has no fixed number of inodes
+	Inodes     uint64
+	InodesFree uint64
+}
+
+// UsedBytes is the space in use.
+func (u *Usage) UsedBytes() uint64 {
+	if u.FreeBytes > u.TotalBytes {
+		return 0
+	}
+
+	return u.TotalBytes - u.FreeBytes
+}
+
+// Resolve makes path absolute and resolves symbolic links. If path does not
+// exist, the nearest ancestor that does is returned instead, as that is where
+// the path would be created.
+func Resolve(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		if _, err := os.Lstat(abs); err == nil {
+			return filepath.EvalSymlinks(abs)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+
+		parent := filepath.Dir(abs)
+
+		if parent == abs {
+			return "", os.ErrNotExist
+		}
+
+		abs = parent
+	}
+}
@@ -0,0 +1,87 @@
+//go:build darwin || freebsd
+
+package mounts
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// List returns all mounted filesystems.
+func List() ([]Mount, error) {
+	n, err := unix.Getfsstat(nil, unix.MNT_NOWAIT)
+
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]unix.Statfs_t, n)
+	n, err = unix.Getfsstat(buf, unix.MNT_NOWAIT)
+
+	if err != nil {
+		return nil, err
+	}
+
+	mounts := make([]Mount, 0, n)
+
+	for i := range buf[:n] {
+		mounts = append(mounts, toMount(&buf[i]))
+	}
+
+	return mounts, nil
+}
+
+// Find returns the mount that contains path.
+func Find(path string) (*Mount, error) {
+	var st unix.Statfs_t
+
+	if err := unix.Statfs(path, &st); err != nil {
+		return nil, err
+	}
+
+	m := toMount(&st)
+
+	return &m, nil
+}
+
+// GetUsage returns the usage of the filesystem that contains path.
+func GetUsage(path string) (*Usage, error) {
+	var st unix.Statfs_t
+
+	if err := unix.Statfs(path, &st); err != nil {
+		return nil, err
+	}
+
+	size := uint64(st.Bsize)
+
+	return &Usage{
+		TotalBytes:     uint64(st.Blocks) * size,
+		FreeBytes:      uint64(st.Bfree) * size,
+		AvailableBytes: uint64(st.Bavail) * size,
+		Inodes:         uint64(st.Files),
+		InodesFree:     uint64(st.Ffree),
+	}, nil
+}
+
+func toMount(st *unix.Statfs_t) Mount {
+	flags := uint64(st.Flags)
+	options := []string{"rw"}
+
+	if flags&unix.MNT_RDONLY != 0 {
+		options[0] = "ro"
+	}
+
+	if flags&unix.MNT_NOSUID != 0 {
+		options = append(options, "nosuid")
+	}
+
+	if flags&unix.MNT_NOEXEC != 0 {
+		options = append(options, "noexec")
+	}
+
+	return Mount{
+		MountPoint: unix.ByteSliceToString(st.Mntonname[:]),
+		Device:     unix.ByteSliceToString(st.Mntfromname[:]),
+		FSType:     unix.ByteSliceToString(st.Fstypename[:]),
+		Options:    options,
+	}
+}
@@ -0,0 +1,55 @@
+//go:build linux
+
+package mounts
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// List returns all mounts visible to this process, in the order they were mounted.
+func List() ([]Mount, error) {
+	return ReadMountInfo(DefaultProcRoot)
+}
+
+// Find returns the mount that contains path, which must have been resolved with Resolve.
+func Find(path string) (*Mount, error) {
+	mounts, err := List()
+
+	if err != nil {
+		return nil, err
+	}
+
+	m, ok := FindMount(mounts, path)
+
+	if !ok {
+		return nil, fmt.Errorf("no mount found for %s", path)
+	}
+
+	return m, nil
+}
+
+// GetUsage returns the usage of the filesystem that contains path.
+func GetUsage(path string) (*Usage, error) {
+	var st unix.Statfs_t
+
+	if err := unix.Statfs(path, &st); err != nil {
+		return nil, err
+	}
+
+	// Block counts are in units of the fragment size, which is usually the same as the block size
+	size := uint64(st.Frsize)
+
+	if size == 0 {
+		size = uint64(st.Bsize)
+	}
+
+	return &Usage{
+		TotalBytes:     st.Blocks * size,
+		FreeBytes:      st.Bfree * size,
+		AvailableBytes: st.Bavail * size,
+		Inodes:         st.Files,
+		InodesFree:     st.Ffree,
+	}, nil
+}
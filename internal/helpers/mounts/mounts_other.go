@@ -0,0 +1,18 @@
+//go:build !linux && !darwin && !freebsd && !windows
+
+package mounts
+
+// List returns all mounted filesystems.
+func List() ([]Mount, error) {
+	return nil, ErrNotSupported
+}
+
+// Find returns the mount that contains path.
+func Find(path string) (*Mount, error) {
+	return nil, ErrNotSupported
+}
+
+// GetUsage returns the usage of the filesystem that contains path.
+func GetUsage(path string) (*Usage, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,122 @@
+package mounts
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const mountinfo = `22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw,errors=remount-ro
+23 22 0:21 / /proc rw,nosuid,nodev,noexec,relatime shared:5 - proc proc rw
+24 22 8:2 / /home rw,relatime shared:2 - xfs /dev/sda2 rw,attr2,inode64
+25 24 0:45 / /home/user/My\040Drive rw,nosuid,nodev shared:30 - fuse.rclone remote:\134share rw,user_id=1000
+26 22 0:50 / /mnt rw,relatime - tmpfs tmpfs rw,size=1024k
+27 22 0:51 / /mnt ro,relatime - nfs4 server:/export ro,vers=4.2
+`
+
+func TestParseMountInfo(t *testing.T) {
+	mounts, err := ParseMountInfo(strings.NewReader(mountinfo))
+	require.NoError(t, err)
+	require.Len(t, mounts, 6)
+
+	require.Equal(t, Mount{
+		MountPoint: "/",
+		Device:     "/dev/sda1",
+		FSType:     "ext4",
+		Options:    []string{"rw", "relatime", "errors=remount-ro"},
+		Root:       "/",
+	}, mounts[0])
+
+	require.Equal(t, []string{"rw", "relatime", "attr2", "inode64"}, mounts[2].Options)
+	require.Equal(t, "/home/user/My Drive", mounts[3].MountPoint)
+	require.Equal(t, `remote:\share`, mounts[3].Device)
+	require.Equal(t, "fuse.rclone", mounts[3].FSType)
+}
+
+func TestParseMountInfoInvalid(t *testing.T) {
+	_, err := ParseMountInfo(strings.NewReader("22 1 8:1 / / rw,relatime\n"))
+	require.ErrorContains(t, err, "line 1")
+}
+
+func TestFindMount(t *testing.T) {
+	mounts, err := ParseMountInfo(strings.NewReader(mountinfo))
+	require.NoError(t, err)
+
+	for path, expected := range map[string]string{
+		"/":                          "/",
+		"/etc/hosts":                 "/",
+		"/home":                      "/home",
+		"/home/user/.bashrc":         "/home",
+		"/homework":                  "/",
+		"/home/user/My Drive/a.txt":  "/home/user/My Drive",
+		"/home/user/My Drive.backup": "/home",
+	} {
+		m, ok := FindMount(mounts, path)
+		require.True(t, ok, path)
+		require.Equal(t, expected, m.MountPoint, path)
+	}
+
+	// The last of the mounts over /mnt is visible
+	m, ok := FindMount(mounts, "/mnt/data")
+	require.True(t, ok)
+	require.Equal(t, "nfs4", m.FSType)
+
+	_, ok = FindMount(nil, "/")
+	require.False(t, ok)
+}
+
+func TestResolve(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	resolved, err := Resolve(dir)
+	require.NoError(t, err)
+	require.Equal(t, dir, resolved)
+
+	// A path that does not exist resolves to its nearest existing ancestor
+	resolved, err = Resolve(filepath.Join(dir, "a", "b"))
+	require.NoError(t, err)
+	require.Equal(t, dir, resolved)
+
+	if runtime.GOOS != "windows" {
+		link := filepath.Join(dir, "link")
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "target"), 0o755))
+		require.NoError(t, os.Symlink(filepath.Join(dir, "target"), link))
+
+		resolved, err = Resolve(filepath.Join(link, "new"))
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, "target"), resolved)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	u := Usage{TotalBytes: 100, FreeBytes: 40, AvailableBytes: 30}
+	require.Equal(t, uint64(60), u.UsedBytes())
+}
+
+func TestLocal(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" && runtime.GOOS != "windows" {
+		t.Skip("mounts are not supported on", runtime.GOOS)
+	}
+
+	dir, err := Resolve(t.TempDir())
+	require.NoError(t, err)
+
+	mounts, err := List()
+	require.NoError(t, err)
+	require.NotEmpty(t, mounts)
+
+	m, err := Find(dir)
+	require.NoError(t, err)
+	require.NotEmpty(t, m.MountPoint)
+	require.NotEmpty(t, m.FSType)
+
+	u, err := GetUsage(dir)
+	require.NoError(t, err)
+	require.NotZero(t, u.TotalBytes)
+	require.LessOrEqual(t, u.AvailableBytes, u.TotalBytes)
+}
@@ -0,0 +1,111 @@
+//go:build windows
+
+package mounts
+
+import (
+	"golang.org/x/sys/windows"
+)
+
+// List returns the volumes that have drive letters.
+func List() ([]Mount, error) {
+	buf := make([]uint16, 256)
+	n, err := windows.GetLogicalDriveStrings(uint32(len(buf)), &buf[0])
+
+	if err != nil {
+		return nil, err
+	}
+
+	var mounts []Mount
+
+	// The drives are separated by NUL, e.g. "C:\\\x00D:\\\x00"
+	for start, i := 0, 0; i < int(n); i++ {
+		if buf[i] != 0 {
+			continue
+		}
+
+		if i > start {
+			root := windows.UTF16ToString(buf[start:i])
+
+			// Drives with no media, e.g. an empty card reader, are skipped
+			if m, err := volume(root); err == nil {
+				mounts = append(mounts, *m)
+			}
+		}
+
+		start = i + 1
+	}
+
+	return mounts, nil
+}
+
+// Find returns the volume that contains path.
+func Find(path string) (*Mount, error) {
+	p, err := windows.UTF16PtrFromString(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]uint16, windows.MAX_PATH+1)
+
+	if err := windows.GetVolumePathName(p, &buf[0], uint32(len(buf))); err != nil {
+		return nil, err
+	}
+
+	return volume(windows.UTF16ToString(buf))
+}
+
+// GetUsage returns the usage of the volume that contains path.
+func GetUsage(path string) (*Usage, error) {
+	p, err := windows.UTF16PtrFromString(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var u Usage
+
+	if err := windows.GetDiskFreeSpaceEx(p, &u.AvailableBytes, &u.TotalBytes, &u.FreeBytes); err != nil {
+		return nil, err
+	}
+
+	return &u, nil
+}
+
+// volume gets the details of the volume mounted at root, e.g. "C:\".
+func volume(root string) (*Mount, error) {
+	p, err := windows.UTF16PtrFromString(root)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var flags uint32
+	fsType := make([]uint16, windows.MAX_PATH+1)
+
+	if err := windows.GetVolumeInformation(p, nil, 0, nil, nil, &flags, &fsType[0], uint32(len(fsType))); err != nil {
+		return nil, err
+	}
+
+	m := &Mount{
+		MountPoint: root,
+		FSType:     windows.UTF16ToString(fsType),
+		Options:    []string{"rw"},
+	}
+
+	if flags&windows.FILE_READ_ONLY_VOLUME != 0 {
+		m.Options[0] = "ro"
+	}
+
+	if windows.GetDriveType(p) == windows.DRIVE_REMOTE {
+		m.Options = append(m.Options, "remote")
+	}
+
+	name := make([]uint16, windows.MAX_PATH+1)
+
+	if err := windows.GetVolumeNameForVolumeMountPoint(p, &name[0], uint32(len(name))); err == nil {
+		m.Device = windows.UTF16ToString(name)
+	}
+
+	return m, nil
+}
@@ -0,0 +1,154 @@
+package neighbors
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultProcRoot is where the proc filesystem is normally mounted.
+const DefaultProcRoot = "/proc"
+
+// Flags in /proc/net/arp, from the kernel's if_arp.h
+const (
+	atfComplete  = 0x02
+	atfPermanent = 0x04
+)
+
+var ErrNotSupported = errors.New("neighbour tables are not supported on this operating system")
+
+// ErrNDP is returned by List with the IPv4 neighbours when the IPv6 neighbour table cannot be read.
+var ErrNDP = errors.New("unable to read IPv6 neighbour table")
+
+type Neighbor struct {
+	Address   string
+	Family    string
+	Interface string
+
+	// MAC is empty if the link layer address is not yet known
+	MAC string
+
+	// State is the kernel's neighbour state, lower cased, e.g. "reachable" or "stale".
+	// For IPv4 it is derived from the ARP flags, so is one of "permanent",
+	// "reachable" or "incomplete".
+	State string
+}
+
+// List returns the neighbours of family, "ipv4", "ipv6" or "" for both. IPv4
+// neighbours are read from procRoot/net/arp and IPv6 neighbours from the kernel
+// via netlink. They are sorted by interface then address.
+//
+// If the IPv6 table cannot be read when both families are requested, the IPv4
+// neighbours are returned with an error wrapping ErrNDP.
+func List(procRoot, family string) ([]*Neighbor, error) {
+	return list(procRoot, family, listNDP)
+}
+
+func list(procRoot, family string, ndp func() ([]*Neighbor, error)) ([]*Neighbor, error) {
+	result := []*Neighbor{}
+
+	if family != "ipv6" {
+		arp, err := ReadProcNetArp(filepath.Join(procRoot, "net", "arp"))
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = arp
+	}
+
+	var ndpErr error
+
+	if family != "ipv4" {
+		ipv6, err := ndp()
+
+		switch {
+		case err == nil:
+			result = append(result, ipv6...)
+		case family == "ipv6":
+			return nil, err
+		default:
+			ndpErr = fmt.Errorf("%w: %w", ErrNDP, err)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Interface != result[j].Interface {
+			return result[i].Interface < result[j].Interface
+		}
+
+		return result[i].Address < result[j].Address
+	})
+
+	return result, ndpErr
+}
+
+// ReadProcNetArp reads the IPv4 neighbour table at path.
+// A missing file is treated as an empty table, as it is when IPv4 is disabled.
+func ReadProcNetArp(path string) ([]*Neighbor, error) {
+	f, err := os.Open(path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return []*Neighbor{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return ParseProcNetArp(f)
+}
+
+// ParseProcNetArp parses the content of /proc/net/arp.
+func ParseProcNetArp(r io.Reader) ([]*Neighbor, error) {
+	result := make([]*Neighbor, 0, 8)
+	scanner := bufio.NewScanner(r)
+
+	// Skip header
+	scanner.Scan()
+
+	for scanner.Scan() {
+		// IP address, HW type, Flags, HW address, Mask, Device
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) < 6 {
+			continue
+		}
+
+		flags, err := strconv.ParseUint(strings.TrimPrefix(fields[2], "0x"), 16, 32)
+
+		if err != nil {
+			continue
+		}
+
+		n := &Neighbor{
+			Address:   fields[0],
+			Family:    "ipv4",
+			Interface: fields[5],
+			State:     "incomplete",
+		}
+
+		switch {
+		case flags&atfPermanent != 0:
+			n.State = "permanent"
+		case flags&atfComplete != 0:
+			n.State = "reachable"
+		}
+
+		if n.State != "incomplete" {
+			n.MAC = fields[3]
+		}
+
+		result = append(result, n)
+	}
+
+	return result, scanner.Err()
+}
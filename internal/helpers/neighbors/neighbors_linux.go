@@ -0,0 +1,140 @@
+//go:build linux
+
+package neighbors
+
+import (
+	"encoding/binary"
+	"net"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/cpu"
+	"golang.org/x/sys/unix"
+)
+
+// nativeEndian is the byte order of netlink messages, which is that of the host.
+var nativeEndian = func() binary.ByteOrder {
+	if cpu.IsBigEndian {
+		return binary.BigEndian
+	}
+
+	return binary.LittleEndian
+}()
+
+// Neighbour states, from the kernel's neighbour.h
+var nudStates = []struct {
+	state uint16
+	name  string
+}{
+	{unix.NUD_PERMANENT, "permanent"},
+	{unix.NUD_NOARP, "noarp"},
+	{unix.NUD_REACHABLE, "reachable"},
+	{unix.NUD_STALE, "stale"},
+	{unix.NUD_DELAY, "delay"},
+	{unix.NUD_PROBE, "probe"},
+	{unix.NUD_FAILED, "failed"},
+	{unix.NUD_INCOMPLETE, "incomplete"},
+}
+
+// listNDP dumps the IPv6 neighbour table via netlink.
+func listNDP() ([]*Neighbor, error) {
+	b, err := syscall.NetlinkRIB(unix.RTM_GETNEIGH, unix.AF_INET6)
+
+	if err != nil {
+		return nil, err
+	}
+
+	msgs, err := syscall.ParseNetlinkMessage(b)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parseNeighMessages(msgs, interfaceName), nil
+}
+
+func interfaceName(index int) string {
+	if ifi, err := net.InterfaceByIndex(index); err == nil {
+		return ifi.Name
+	}
+
+	return ""
+}
+
+func parseNeighMessages(msgs []syscall.NetlinkMessage, ifName func(int) string) []*Neighbor {
+	result := make([]*Neighbor, 0, len(msgs))
+
+	for _, m := range msgs {
+		if m.Header.Type != unix.RTM_NEWNEIGH || len(m.Data) < unix.SizeofNdMsg {
+			continue
+		}
+
+		family := m.Data[0]
+		ifindex := int32(nativeEndian.Uint32(m.Data[4:8]))
+		state := nativeEndian.Uint16(m.Data[8:10])
+
+		var n *Neighbor
+
+		switch family {
+		case unix.AF_INET6:
+			n = &Neighbor{Family: "ipv6"}
+		case unix.AF_INET:
+			n = &Neighbor{Family: "ipv4"}
+		default:
+			continue
+		}
+
+		n.Interface = ifName(int(ifindex))
+		n.State = nudStateName(state)
+
+		for attrs := m.Data[unix.SizeofNdMsg:]; len(attrs) >= unix.SizeofRtAttr; {
+			length := int(nativeEndian.Uint16(attrs[0:2]))
+			attrType := nativeEndian.Uint16(attrs[2:4])
+
+			if length < unix.SizeofRtAttr || length > len(attrs) {
+				break
+			}
+
+			value := attrs[unix.SizeofRtAttr:length]
+
+			switch attrType {
+			case unix.NDA_DST:
+				n.Address = net.IP(value).String()
+			case unix.NDA_LLADDR:
+				n.MAC = net.HardwareAddr(value).String()
+			}
+
+			// Attributes are aligned to 4 bytes
+			aligned := (length + unix.RTA_ALIGNTO - 1) &^ (unix.RTA_ALIGNTO - 1)
+
+			if aligned > len(attrs) {
+				break
+			}
+
+			attrs = attrs[aligned:]
+		}
+
+		// Multicast entries are mappings for group addresses, not neighbouring hosts
+		if ip := net.ParseIP(n.Address); ip != nil && !ip.IsMulticast() {
+			result = append(result, n)
+		}
+	}
+
+	return result
+}
+
+func nudStateName(state uint16) string {
+	names := make([]string, 0, 1)
+
+	for _, s := range nudStates {
+		if state&s.state != 0 {
+			names = append(names, s.name)
+		}
+	}
+
+	if len(names) == 0 {
+		return "none"
+	}
+
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,71 @@
+//go:build linux
+
+package neighbors
+
+import (
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func rtattr(attrType uint16, value []byte) []byte {
+	length := unix.SizeofRtAttr + len(value)
+	b := make([]byte, (length+unix.RTA_ALIGNTO-1)&^(unix.RTA_ALIGNTO-1))
+	nativeEndian.PutUint16(b[0:2], uint16(length))
+	nativeEndian.PutUint16(b[2:4], attrType)
+	copy(b[unix.SizeofRtAttr:], value)
+	return b
+}
+
+func neighMessage(family uint8, ifindex int32, state uint16, attrs ...[]byte) syscall.NetlinkMessage {
+	data := make([]byte, unix.SizeofNdMsg)
+	data[0] = family
+	nativeEndian.PutUint32(data[4:8], uint32(ifindex))
+	nativeEndian.PutUint16(data[8:10], state)
+
+	for _, a := range attrs {
+		data = append(data, a...)
+	}
+
+	return syscall.NetlinkMessage{
+		Header: syscall.NlMsghdr{Type: unix.RTM_NEWNEIGH},
+		Data:   data,
+	}
+}
+
+func TestParseNeighMessages(t *testing.T) {
+	mac, _ := net.ParseMAC("aa:bb:cc:dd:ee:06")
+	names := map[int]string{2: "eth0"}
+
+	n := parseNeighMessages([]syscall.NetlinkMessage{
+		neighMessage(unix.AF_INET6, 2, unix.NUD_STALE,
+			rtattr(unix.NDA_DST, net.ParseIP("fe80::1")),
+			rtattr(unix.NDA_LLADDR, mac),
+		),
+		neighMessage(unix.AF_INET6, 2, unix.NUD_INCOMPLETE,
+			rtattr(unix.NDA_DST, net.ParseIP("2001:db8::2")),
+		),
+		neighMessage(unix.AF_INET6, 2, unix.NUD_NOARP,
+			rtattr(unix.NDA_DST, net.ParseIP("ff02::16")),
+		),
+		// No destination
+		neighMessage(unix.AF_INET6, 2, unix.NUD_REACHABLE),
+		{Header: syscall.NlMsghdr{Type: unix.NLMSG_DONE}},
+	}, func(i int) string { return names[i] })
+
+	require.Len(t, n, 2)
+	require.Equal(t, &Neighbor{Address: "fe80::1", Family: "ipv6", Interface: "eth0", MAC: "aa:bb:cc:dd:ee:06", State: "stale"}, n[0])
+	require.Equal(t, "2001:db8::2", n[1].Address)
+	require.Empty(t, n[1].MAC)
+	require.Equal(t, "incomplete", n[1].State)
+}
+
+func TestListNDP(t *testing.T) {
+	// Permitted for unprivileged users, but may be blocked in a sandbox
+	if _, err := listNDP(); err != nil {
+		t.Skipf("netlink unavailable: %v", err)
+	}
+}
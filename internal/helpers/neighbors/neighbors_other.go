@@ -0,0 +1,7 @@
+//go:build !linux
+
+package neighbors
+
+func listNDP() ([]*Neighbor, error) {
+	return nil, ErrNotSupported
+}
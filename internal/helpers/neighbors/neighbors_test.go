@@ -0,0 +1,87 @@
+package neighbors
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const procNetArp = `IP address       HW type     Flags       HW address            Mask     Device
+192.168.1.1      0x1         0x2         aa:bb:cc:dd:ee:01     *        eth0
+192.168.1.20     0x1         0x0         00:00:00:00:00:00     *        eth0
+10.0.0.5         0x1         0x6         aa:bb:cc:dd:ee:05     *        wlan0
+`
+
+func TestParseProcNetArp(t *testing.T) {
+	n, err := ParseProcNetArp(strings.NewReader(procNetArp))
+
+	require.NoError(t, err)
+	require.Len(t, n, 3)
+	require.Equal(t, &Neighbor{Address: "192.168.1.1", Family: "ipv4", Interface: "eth0", MAC: "aa:bb:cc:dd:ee:01", State: "reachable"}, n[0])
+	require.Equal(t, "incomplete", n[1].State)
+	require.Empty(t, n[1].MAC)
+	require.Equal(t, "permanent", n[2].State)
+	require.Equal(t, "wlan0", n[2].Interface)
+}
+
+func TestReadProcNetArpMissing(t *testing.T) {
+	n, err := ReadProcNetArp(t.TempDir() + "/arp")
+
+	require.NoError(t, err)
+	require.Empty(t, n)
+}
+
+func procRoot(t *testing.T) string {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "net"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "net", "arp"), []byte(procNetArp), 0o644))
+
+	return root
+}
+
+func TestListFamily(t *testing.T) {
+	root := procRoot(t)
+	ndp := func() ([]*Neighbor, error) {
+		return []*Neighbor{{Address: "fe80::1", Family: "ipv6", Interface: "eth0", State: "stale"}}, nil
+	}
+
+	n, err := list(root, "", ndp)
+	require.NoError(t, err)
+	require.Len(t, n, 4)
+	require.Equal(t, "192.168.1.1", n[0].Address)
+	require.Equal(t, "fe80::1", n[2].Address)
+	require.Equal(t, "wlan0", n[3].Interface)
+
+	n, err = list(root, "ipv6", ndp)
+	require.NoError(t, err)
+	require.Len(t, n, 1)
+
+	// NDP is not read for IPv4 only, so does not fail
+	n, err = list(root, "ipv4", func() ([]*Neighbor, error) {
+		t.Fatal("NDP read for ipv4")
+		return nil, nil
+	})
+	require.NoError(t, err)
+	require.Len(t, n, 3)
+}
+
+func TestListNDPFailure(t *testing.T) {
+	root := procRoot(t)
+	failed := errors.New("netlink: permission denied")
+	ndp := func() ([]*Neighbor, error) {
+		return nil, failed
+	}
+
+	n, err := list(root, "", ndp)
+	require.ErrorIs(t, err, ErrNDP)
+	require.ErrorIs(t, err, failed)
+	require.Len(t, n, 3)
+
+	_, err = list(root, "ipv6", ndp)
+	require.ErrorIs(t, err, failed)
+	require.NotErrorIs(t, err, ErrNDP)
+}
@@ -0,0 +1,126 @@
+package osrelease
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+// DefaultPaths are the locations of the os-release file, in order of precedence.
+// See os-release(5).
+var DefaultPaths = []string{"/etc/os-release", "/usr/lib/os-release"}
+
+// Release holds the fields of an os-release file.
+type Release struct {
+	// Fields holds all fields, with quotes and escapes removed
+	Fields map[string]string
+}
+
+// ID is the lower case identifier of the distribution, e.g. "ubuntu". It defaults to "linux".
+func (r *Release) ID() string {
+	if id := r.Fields["ID"]; id != "" {
+		return id
+	}
+
+	return "linux"
+}
+
+// IDLike lists the identifiers of distributions this one is derived from, closest first.
+func (r *Release) IDLike() []string {
+	return strings.Fields(r.Fields["ID_LIKE"])
+}
+
+// Get returns a field, or "" if it is not set.
+func (r *Release) Get(name string) string {
+	return r.Fields[name]
+}
+
+// PrettyName is the name of the distribution to show to users. It defaults to "Linux".
+func (r *Release) PrettyName() string {
+	if n := r.Fields["PRETTY_NAME"]; n != "" {
+		return n
+	}
+
+	return "Linux"
+}
+
+// Read reads the first of paths that exists. If none exist, the error wraps os.ErrNotExist.
+func Read(paths ...string) (*Release, error) {
+	for _, p := range paths {
+		f, err := os.Open(p)
+
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		defer f.Close()
+
+		return Parse(f)
+	}
+
+	return nil, os.ErrNotExist
+}
+
+// Parse reads the content of an os-release file, which consists of
+// shell-compatible variable assignments without expansions.
+func Parse(r io.Reader) (*Release, error) {
+	release := &Release{
+		Fields: make(map[string]string),
+	}
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+
+		if !ok {
+			continue
+		}
+
+		release.Fields[key] = unquote(value)
+	}
+
+	return release, scanner.Err()
+}
+
+// unquote removes the quotes from a value, and the backslash escapes from a
+// double-quoted value, as a shell would.
+func unquote(s string) string {
+	if len(s) < 2 {
+		return s
+	}
+
+	switch {
+	case s[0] == '\'' && s[len(s)-1] == '\'':
+		return s[1 : len(s)-1]
+
+	case s[0] == '"' && s[len(s)-1] == '"':
+		s = s[1 : len(s)-1]
+
+		var b strings.Builder
+
+		for i := 0; i < len(s); i++ {
+			if s[i] == '\\' && i+1 < len(s) && strings.IndexByte("\"\\$`", s[i+1]) >= 0 {
+				i++
+			}
+
+			b.WriteByte(s[i])
+		}
+
+		return b.String()
+	}
+
+	return s
+}
@@ -0,0 +1,70 @@
+package osrelease
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const ubuntu = `PRETTY_NAME="Ubuntu 22.04.3 LTS"
+NAME="Ubuntu"
+VERSION_ID="22.04"
+VERSION="22.04.3 LTS (Jammy Jellyfish)"
+VERSION_CODENAME=jammy
+ID=ubuntu
+ID_LIKE=debian
+HOME_URL="https://www.ubuntu.com/"
+`
+
+func TestParse(t *testing.T) {
+	r, err := Parse(strings.NewReader(ubuntu))
+	require.NoError(t, err)
+
+	require.Equal(t, "ubuntu", r.ID())
+	require.Equal(t, []string{"debian"}, r.IDLike())
+	require.Equal(t, "22.04", r.Get("VERSION_ID"))
+	require.Equal(t, "jammy", r.Get("VERSION_CODENAME"))
+	require.Equal(t, "Ubuntu 22.04.3 LTS", r.PrettyName())
+	require.Equal(t, "https://www.ubuntu.com/", r.Get("HOME_URL"))
+}
+
+func TestParseQuoting(t *testing.T) {
+	r, err := Parse(strings.NewReader(`# comment
+ID_LIKE="rhel centos fedora"
+NAME='Single quoted'
+PRETTY_NAME="With \"escapes\" and \$dollar"
+EMPTY=
+`))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"rhel", "centos", "fedora"}, r.IDLike())
+	require.Equal(t, "Single quoted", r.Get("NAME"))
+	require.Equal(t, `With "escapes" and $dollar`, r.Get("PRETTY_NAME"))
+	require.Equal(t, "", r.Get("EMPTY"))
+}
+
+func TestDefaults(t *testing.T) {
+	r, err := Parse(strings.NewReader(""))
+	require.NoError(t, err)
+
+	require.Equal(t, "linux", r.ID())
+	require.Equal(t, "Linux", r.PrettyName())
+	require.Empty(t, r.IDLike())
+}
+
+func TestRead(t *testing.T) {
+	dir := t.TempDir()
+	lib := filepath.Join(dir, "os-release")
+	require.NoError(t, os.WriteFile(lib, []byte("ID=alpine\n"), 0o644))
+
+	r, err := Read(filepath.Join(dir, "missing"), lib)
+	require.NoError(t, err)
+	require.Equal(t, "alpine", r.ID())
+
+	_, err = Read(filepath.Join(dir, "missing"))
+	require.True(t, errors.Is(err, os.ErrNotExist))
+}
@@ -0,0 +1,179 @@
+package proxyconfig
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Variables are the environment variables that configure proxies, in the order
+// of precedence used by Go's http.ProxyFromEnvironment, i.e. upper case first.
+// ALL_PROXY is not used by Go, but is honoured by curl and many other tools.
+var Variables = []string{
+	"HTTP_PROXY", "http_proxy",
+	"HTTPS_PROXY", "https_proxy",
+	"NO_PROXY", "no_proxy",
+	"ALL_PROXY", "all_proxy",
+}
+
+// Settings is the effective proxy configuration.
+type Settings struct {
+	HTTPProxy  string
+	HTTPSProxy string
+	NoProxy    string
+	AllProxy   string
+
+	// Variables holds each of Variables that is set to a non-empty value
+	Variables map[string]string
+}
+
+// Lookup is the signature of os.LookupEnv.
+type Lookup func(key string) (string, bool)
+
+// New gets the settings from the given variable lookups, in order of precedence.
+// Each setting is taken from the first lookup that has a non-empty value for it,
+// upper case first, so that e.g. http_proxy in the environment takes precedence
+// over HTTP_PROXY in a file.
+func New(lookups ...Lookup) *Settings {
+	s := &Settings{
+		Variables: make(map[string]string),
+	}
+
+	for _, v := range Variables {
+		for _, lookup := range lookups {
+			if value, ok := lookup(v); ok && value != "" {
+				s.Variables[v] = value
+				break
+			}
+		}
+	}
+
+	get := func(names ...string) string {
+		for _, lookup := range lookups {
+			for _, n := range names {
+				if value, ok := lookup(n); ok && value != "" {
+					return value
+				}
+			}
+		}
+
+		return ""
+	}
+
+	s.HTTPProxy = get("HTTP_PROXY", "http_proxy")
+	s.HTTPSProxy = get("HTTPS_PROXY", "https_proxy")
+	s.NoProxy = get("NO_PROXY", "no_proxy")
+	s.AllProxy = get("ALL_PROXY", "all_proxy")
+
+	return s
+}
+
+// NoProxyList returns the comma separated entries of NoProxy.
+func (s *Settings) NoProxyList() []string {
+	result := make([]string, 0, 8)
+
+	for _, p := range strings.Split(s.NoProxy, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			result = append(result, p)
+		}
+	}
+
+	return result
+}
+
+// ProxyFor returns the proxy that http.ProxyFromEnvironment would select for target,
+// or nil if target would be connected to directly.
+func (s *Settings) ProxyFor(target string) (*url.URL, error) {
+	u, err := url.Parse(target)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("%q is not an absolute URL", target)
+	}
+
+	cfg := &httpproxy.Config{
+		HTTPProxy:  s.HTTPProxy,
+		HTTPSProxy: s.HTTPSProxy,
+		NoProxy:    s.NoProxy,
+	}
+
+	return cfg.ProxyFunc()(u)
+}
+
+// Redact replaces any password in a proxy URL with "xxxxx".
+// Values that do not parse as URLs are returned unchanged.
+func Redact(value string) string {
+	// Proxies may be given without a scheme, e.g. "user:secret@proxy:3128",
+	// which would otherwise parse as scheme "user"
+	const defaultScheme = "http://"
+	schemeless := !strings.Contains(value, "://")
+
+	if schemeless {
+		value = defaultScheme + value
+	}
+
+	u, err := url.Parse(value)
+
+	if err == nil && u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			value = u.Redacted()
+		}
+	}
+
+	if schemeless {
+		value = strings.TrimPrefix(value, defaultScheme)
+	}
+
+	return value
+}
+
+// ReadFile reads proxy variables from a file of KEY=value lines, such as /etc/environment
+// or a shell profile script. Lines may begin with "export". Other lines are ignored.
+func ReadFile(path string) (Lookup, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+		key, value, ok := strings.Cut(line, "=")
+
+		if !ok || strings.HasPrefix(key, "#") {
+			continue
+		}
+
+		values[strings.TrimSpace(key)] = unquote(strings.TrimSpace(value))
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return func(key string) (string, bool) {
+		v, ok := values[key]
+		return v, ok
+	}, nil
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+
+	return s
+}
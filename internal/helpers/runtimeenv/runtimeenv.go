@@ -0,0 +1,306 @@
+package runtimeenv
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/cpuinfo"
+)
+
+// DefaultRoot is the root of the filesystem in which the marker files are looked for.
+const DefaultRoot = "/"
+
+// Container engines.
+const (
+	Docker = "docker"
+	Podman = "podman"
+	LXC    = "lxc"
+	Nspawn = "systemd-nspawn"
+)
+
+// Environment describes what terraform is running in.
+type Environment struct {
+	// IsContainer is true in a container, even if the engine is not known
+	IsContainer bool
+
+	// Container is the engine, e.g. "docker", "podman", "lxc" or "systemd-nspawn", if known
+	Container string
+
+	IsKubernetes        bool
+	KubernetesNamespace string
+
+	// WSLVersion is 1 or 2 in the Windows Subsystem for Linux, otherwise 0
+	WSLVersion int
+	WSLDistro  string
+
+	// IsVirtualMachine is true in a virtual machine, even if the hypervisor is not known
+	IsVirtualMachine bool
+
+	// Hypervisor is e.g. "kvm", "vmware" or "hyperv", if known
+	Hypervisor string
+
+	// Evidence lists what was found, e.g. "/.dockerenv exists"
+	Evidence []string
+}
+
+// dmiVendors maps the DMI vendor or product names of virtual machines to a hypervisor.
+// They are matched as prefixes, in order. Where product is set, the vendor also makes
+// physical machines, so the DMI product_name must also start with product.
+var dmiVendors = []struct{ prefix, product, hypervisor string }{
+	{"KVM", "", "kvm"},
+	{"QEMU", "", "qemu"},
+	{"VMware", "", "vmware"},
+	{"VMW", "", "vmware"},
+	{"innotek GmbH", "", "virtualbox"},
+	{"VirtualBox", "", "virtualbox"},
+	{"Oracle Corporation", "VirtualBox", "virtualbox"},
+	{"Microsoft Corporation", "Virtual Machine", "hyperv"},
+	{"Xen", "", "xen"},
+	{"Bochs", "", "bochs"},
+	{"Parallels", "", "parallels"},
+	{"BHYVE", "", "bhyve"},
+	{"Amazon EC2", "", "amazon"},
+	{"Google", "Google Compute Engine", "google"},
+	{"OpenStack", "", "openstack"},
+	{"Apple Virtualization", "", "apple"},
+}
+
+// Detect examines the filesystem under root, and the environment using getenv.
+func Detect(root string, getenv func(string) string) *Environment {
+	d := &detector{root: root, getenv: getenv, env: &Environment{}}
+
+	d.container()
+	d.kubernetes()
+	d.wsl()
+	d.hypervisor()
+
+	return d.env
+}
+
+type detector struct {
+	root   string
+	getenv func(string) string
+	env    *Environment
+}
+
+func (d *detector) path(name string) string {
+	return filepath.Join(d.root, filepath.FromSlash(name))
+}
+
+func (d *detector) exists(name string) bool {
+	_, err := os.Stat(d.path(name))
+	return err == nil
+}
+
+func (d *detector) read(name string) string {
+	b, err := os.ReadFile(d.path(name))
+
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(b))
+}
+
+func (d *detector) evidence(s string) {
+	d.env.Evidence = append(d.env.Evidence, s)
+}
+
+// setContainer records a container, keeping the first engine found. engine is "" if not known.
+func (d *detector) setContainer(engine, evidence string) {
+	d.env.IsContainer = true
+
+	if d.env.Container == "" {
+		d.env.Container = engine
+	}
+
+	d.evidence(evidence)
+}
+
+func (d *detector) container() {
+	if d.exists("run/.containerenv") {
+		d.setContainer(Podman, "/run/.containerenv exists")
+	}
+
+	if d.exists(".dockerenv") {
+		d.setContainer(Docker, "/.dockerenv exists")
+	}
+
+	// Set by systemd-nspawn, LXC and podman, and written to /run/systemd/container by systemd in the container
+	for _, kind := range []string{d.getenv("container"), d.read("run/systemd/container")} {
+		switch kind {
+		case "":
+		case "wsl":
+			// systemd in WSL reports it as a container, but it is a whole distribution
+		case "oci":
+			d.setContainer("", "container is oci")
+		default:
+			d.setContainer(kind, "container is "+kind)
+		}
+	}
+
+	// Without a cgroup namespace, the cgroup path shows the engine
+	for _, line := range strings.Split(d.read("proc/self/cgroup"), "\n") {
+		if _, _, path := cut3(line); path != "" {
+			if engine, ok := cgroupEngine(path); ok {
+				d.setContainer(engine, "cgroup is "+path)
+				break
+			}
+		}
+	}
+}
+
+// cgroupEngine gets the container engine from a cgroup path.
+func cgroupEngine(path string) (string, bool) {
+	switch {
+	case strings.Contains(path, "/libpod-") || strings.Contains(path, "/libpod_parent"):
+		return Podman, true
+	case strings.Contains(path, "/docker/") || strings.Contains(path, "/docker-"):
+		return Docker, true
+	case strings.HasPrefix(path, "/lxc/") || strings.HasPrefix(path, "/lxc.payload"):
+		return LXC, true
+	case strings.Contains(path, "/machine.slice/systemd-nspawn@"):
+		return Nspawn, true
+	case strings.Contains(path, "/kubepods"):
+		return "", true
+	}
+
+	return "", false
+}
+
+func (d *detector) kubernetes() {
+	if d.exists("var/run/secrets/kubernetes.io/serviceaccount") {
+		d.env.IsKubernetes = true
+		d.env.KubernetesNamespace = d.read("var/run/secrets/kubernetes.io/serviceaccount/namespace")
+		d.evidence("/var/run/secrets/kubernetes.io/serviceaccount exists")
+	}
+
+	if d.getenv("KUBERNETES_SERVICE_HOST") != "" {
+		d.env.IsKubernetes = true
+		d.evidence("KUBERNETES_SERVICE_HOST is set")
+	}
+
+	if d.env.IsKubernetes {
+		d.env.IsContainer = true
+
+		if ns := d.getenv("POD_NAMESPACE"); d.env.KubernetesNamespace == "" && ns != "" {
+			d.env.KubernetesNamespace = ns
+		}
+	}
+}
+
+func (d *detector) wsl() {
+	// WSL1 kernels are e.g. "4.4.0-19041-Microsoft", WSL2 kernels "5.15.90.1-microsoft-standard-WSL2"
+	release := d.read("proc/sys/kernel/osrelease")
+
+	if release == "" {
+		release = d.read("proc/version")
+	}
+
+	switch {
+	case strings.Contains(release, "Microsoft"):
+		d.env.WSLVersion = 1
+		d.evidence("kernel " + firstWord(release) + " is WSL1")
+	case strings.Contains(release, "microsoft"):
+		d.env.WSLVersion = 2
+		d.evidence("kernel " + firstWord(release) + " is WSL2")
+	}
+
+	if distro := d.getenv("WSL_DISTRO_NAME"); distro != "" {
+		d.env.WSLDistro = distro
+		d.evidence("WSL_DISTRO_NAME is " + distro)
+
+		if d.env.WSLVersion == 0 {
+			d.env.WSLVersion = 1
+
+			// Only WSL2 has an interop socket per distribution
+			if d.getenv("WSL_INTEROP") != "" {
+				d.env.WSLVersion = 2
+			}
+		}
+	}
+}
+
+func (d *detector) hypervisor() {
+	if t := d.read("sys/hypervisor/type"); t != "" {
+		d.setHypervisor(t, "/sys/hypervisor/type is "+t)
+	}
+
+	product := d.read("sys/class/dmi/id/product_name")
+
+	for _, name := range []string{"sys_vendor", "product_name", "bios_vendor", "board_vendor"} {
+		value := d.read("sys/class/dmi/id/" + name)
+
+		if value == "" {
+			continue
+		}
+
+		for _, v := range dmiVendors {
+			if !strings.HasPrefix(value, v.prefix) || !strings.HasPrefix(product, v.product) {
+				continue
+			}
+
+			// EC2 bare metal instances have the same vendor, and instance types such as m5.metal
+			if v.hypervisor == "amazon" && strings.HasSuffix(product, ".metal") {
+				break
+			}
+
+			d.setHypervisor(v.hypervisor, "DMI "+name+" is "+value)
+			break
+		}
+	}
+
+	if f, err := os.Open(d.path("proc/cpuinfo")); err == nil {
+		defer f.Close()
+
+		if processors, err := cpuinfo.ParseCPUInfo(f); err == nil && len(processors) > 0 {
+			for _, flag := range strings.Fields(processors[0]["flags"]) {
+				if flag == "hypervisor" {
+					d.env.IsVirtualMachine = true
+					d.evidence("cpu has the hypervisor flag")
+				}
+			}
+		}
+	}
+
+	// WSL2 runs in a Hyper-V utility VM that has no DMI
+	if d.env.WSLVersion == 2 && d.env.Hypervisor == "" {
+		d.setHypervisor("hyperv", "WSL2 runs in Hyper-V")
+	}
+}
+
+// setHypervisor records a virtual machine, keeping the first hypervisor found.
+func (d *detector) setHypervisor(hypervisor, evidence string) {
+	d.env.IsVirtualMachine = true
+
+	if d.env.Hypervisor == "" {
+		d.env.Hypervisor = hypervisor
+	}
+
+	d.evidence(evidence)
+}
+
+// cut3 splits a line of /proc/<pid>/cgroup.
+func cut3(line string) (string, string, string) {
+	parts := strings.SplitN(line, ":", 3)
+
+	if len(parts) != 3 {
+		return "", "", ""
+	}
+
+	return parts[0], parts[1], parts[2]
+}
+
+func firstWord(s string) string {
+	if f := strings.Fields(s); len(f) > 0 {
+		// /proc/version is "Linux version <release> ..."
+		if len(f) > 2 && f[0] == "Linux" && f[1] == "version" {
+			return f[2]
+		}
+
+		return f[0]
+	}
+
+	return s
+}
@@ -0,0 +1,202 @@
+package runtimeenv
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func fakeRoot(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	return dir
+}
+
+func fakeEnv(vars map[string]string) func(string) string {
+	return func(key string) string {
+		return vars[key]
+	}
+}
+
+func TestDetectNothing(t *testing.T) {
+	root := fakeRoot(t, map[string]string{
+		"proc/self/cgroup":            "0::/user.slice/user-1000.slice/session-2.scope\n",
+		"proc/sys/kernel/osrelease":   "6.5.0-14-generic\n",
+		"proc/cpuinfo":                "processor\t: 0\nflags\t\t: fpu vme de pse\n",
+		"sys/class/dmi/id/sys_vendor": "LENOVO\n",
+	})
+
+	env := Detect(root, fakeEnv(nil))
+	require.Equal(t, &Environment{}, env)
+}
+
+func TestDetectDocker(t *testing.T) {
+	root := fakeRoot(t, map[string]string{
+		".dockerenv":       "",
+		"proc/self/cgroup": "12:memory:/docker/0123456789abcdef\n0::/docker/0123456789abcdef\n",
+	})
+
+	env := Detect(root, fakeEnv(nil))
+	require.True(t, env.IsContainer)
+	require.Equal(t, Docker, env.Container)
+	require.False(t, env.IsKubernetes)
+	require.Equal(t, []string{"/.dockerenv exists", "cgroup is /docker/0123456789abcdef"}, env.Evidence)
+}
+
+func TestDetectPodman(t *testing.T) {
+	root := fakeRoot(t, map[string]string{
+		"run/.containerenv": "engine=\"podman-4.6.1\"\n",
+		"proc/self/cgroup":  "0::/\n",
+	})
+
+	env := Detect(root, fakeEnv(map[string]string{"container": "podman"}))
+	require.True(t, env.IsContainer)
+	require.Equal(t, Podman, env.Container)
+}
+
+func TestDetectNspawn(t *testing.T) {
+	root := fakeRoot(t, map[string]string{
+		"run/systemd/container": "systemd-nspawn\n",
+	})
+
+	env := Detect(root, fakeEnv(nil))
+	require.True(t, env.IsContainer)
+	require.Equal(t, Nspawn, env.Container)
+}
+
+func TestDetectKubernetes(t *testing.T) {
+	root := fakeRoot(t, map[string]string{
+		"var/run/secrets/kubernetes.io/serviceaccount/namespace": "ci-runners",
+		"proc/self/cgroup": "0::/\n",
+	})
+
+	env := Detect(root, fakeEnv(map[string]string{"KUBERNETES_SERVICE_HOST": "10.96.0.1"}))
+	require.True(t, env.IsContainer)
+	require.True(t, env.IsKubernetes)
+	require.Equal(t, "", env.Container)
+	require.Equal(t, "ci-runners", env.KubernetesNamespace)
+}
+
+func TestDetectWSL1(t *testing.T) {
+	root := fakeRoot(t, map[string]string{
+		"proc/sys/kernel/osrelease": "4.4.0-19041-Microsoft\n",
+	})
+
+	env := Detect(root, fakeEnv(map[string]string{"WSL_DISTRO_NAME": "Ubuntu"}))
+	require.Equal(t, 1, env.WSLVersion)
+	require.Equal(t, "Ubuntu", env.WSLDistro)
+	require.False(t, env.IsVirtualMachine)
+}
+
+func TestDetectWSL2(t *testing.T) {
+	root := fakeRoot(t, map[string]string{
+		"proc/sys/kernel/osrelease": "5.15.133.1-microsoft-standard-WSL2\n",
+		"proc/cpuinfo":              "processor\t: 0\nflags\t\t: fpu vme hypervisor lahf_lm\n",
+		"run/systemd/container":     "wsl\n",
+	})
+
+	env := Detect(root, fakeEnv(map[string]string{"WSL_DISTRO_NAME": "Debian"}))
+	require.Equal(t, 2, env.WSLVersion)
+	require.Equal(t, "Debian", env.WSLDistro)
+	require.False(t, env.IsContainer)
+	require.True(t, env.IsVirtualMachine)
+	require.Equal(t, "hyperv", env.Hypervisor)
+}
+
+func TestDetectWSLFromEnvironment(t *testing.T) {
+	env := Detect(t.TempDir(), fakeEnv(map[string]string{"WSL_DISTRO_NAME": "Ubuntu", "WSL_INTEROP": "/run/WSL/8_interop"}))
+	require.Equal(t, 2, env.WSLVersion)
+}
+
+func TestDetectHypervisor(t *testing.T) {
+	for name, tc := range map[string]struct {
+		files      map[string]string
+		hypervisor string
+	}{
+		"kvm": {map[string]string{
+			"sys/class/dmi/id/sys_vendor":   "QEMU\n",
+			"sys/class/dmi/id/product_name": "KVM Virtual Machine\n",
+		}, "qemu"},
+		"vmware": {map[string]string{
+			"sys/class/dmi/id/sys_vendor": "VMware, Inc.\n",
+		}, "vmware"},
+		"hyperv": {map[string]string{
+			"sys/class/dmi/id/sys_vendor":   "Microsoft Corporation\n",
+			"sys/class/dmi/id/product_name": "Virtual Machine\n",
+		}, "hyperv"},
+		"virtualbox": {map[string]string{
+			"sys/class/dmi/id/sys_vendor": "innotek GmbH\n",
+		}, "virtualbox"},
+		"xen": {map[string]string{
+			"sys/hypervisor/type": "xen\n",
+		}, "xen"},
+		"virtualbox oracle": {map[string]string{
+			"sys/class/dmi/id/sys_vendor":   "Oracle Corporation\n",
+			"sys/class/dmi/id/product_name": "VirtualBox\n",
+		}, "virtualbox"},
+		"ec2": {map[string]string{
+			"sys/class/dmi/id/sys_vendor": "Amazon EC2\n",
+		}, "amazon"},
+		"ec2 instance type": {map[string]string{
+			"sys/class/dmi/id/sys_vendor":   "Amazon EC2\n",
+			"sys/class/dmi/id/product_name": "m5.large\n",
+		}, "amazon"},
+		"gce": {map[string]string{
+			"sys/class/dmi/id/sys_vendor":   "Google\n",
+			"sys/class/dmi/id/product_name": "Google Compute Engine\n",
+		}, "google"},
+		"flag only": {map[string]string{
+			"proc/cpuinfo": "processor\t: 0\nflags\t\t: fpu hypervisor\n",
+		}, ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			env := Detect(fakeRoot(t, tc.files), fakeEnv(nil))
+			require.True(t, env.IsVirtualMachine)
+			require.Equal(t, tc.hypervisor, env.Hypervisor)
+			require.NotEmpty(t, env.Evidence)
+		})
+	}
+
+}
+
+func TestDetectBareMetal(t *testing.T) {
+	// Vendors of hypervisors that also make physical machines
+	for name, files := range map[string]map[string]string{
+		"surface": {
+			"sys/class/dmi/id/sys_vendor":   "Microsoft Corporation\n",
+			"sys/class/dmi/id/product_name": "Surface Laptop 4\n",
+		},
+		"oracle server": {
+			"sys/class/dmi/id/sys_vendor":   "Oracle Corporation\n",
+			"sys/class/dmi/id/product_name": "ORACLE SERVER X8-2\n",
+			"sys/class/dmi/id/board_vendor": "Oracle Corporation\n",
+		},
+		"chromebook": {
+			"sys/class/dmi/id/sys_vendor":   "Google\n",
+			"sys/class/dmi/id/product_name": "Eve\n",
+			"sys/class/dmi/id/bios_vendor":  "coreboot\n",
+		},
+		"ec2 metal": {
+			"sys/class/dmi/id/sys_vendor":   "Amazon EC2\n",
+			"sys/class/dmi/id/product_name": "m5.metal\n",
+			"sys/class/dmi/id/bios_vendor":  "Amazon EC2\n",
+			"sys/class/dmi/id/board_vendor": "Amazon EC2\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			env := Detect(fakeRoot(t, files), fakeEnv(nil))
+			require.False(t, env.IsVirtualMachine)
+			require.Empty(t, env.Hypervisor)
+		})
+	}
+}
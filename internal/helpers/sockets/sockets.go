@@ -0,0 +1,262 @@
+package sockets
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultProcRoot is where the proc filesystem is normally mounted.
+const DefaultProcRoot = "/proc"
+
+const (
+	// TCP_LISTEN in the kernel's tcp_states.h
+	tcpListen = "0A"
+
+	// TCP_CLOSE, which is the state reported for bound but unconnected UDP sockets
+	udpUnconnected = "07"
+)
+
+type Socket struct {
+	Protocol string
+	Family   string
+	Address  string
+	Port     int
+	Inode    uint64
+	PID      int
+	Process  string
+}
+
+type procNetFile struct {
+	name     string
+	protocol string
+	family   string
+}
+
+var procNetFiles = []procNetFile{
+	{name: "tcp", protocol: "tcp", family: "ipv4"},
+	{name: "tcp6", protocol: "tcp", family: "ipv6"},
+	{name: "udp", protocol: "udp", family: "ipv4"},
+	{name: "udp6", protocol: "udp", family: "ipv6"},
+}
+
+// Scan reads the listening sockets from the proc filesystem mounted at procRoot
+// and, where permissions allow, attributes each one to the process that owns it.
+func Scan(procRoot string) ([]*Socket, error) {
+	result := make([]*Socket, 0, 16)
+	found := false
+
+	for _, f := range procNetFiles {
+		fd, err := os.Open(filepath.Join(procRoot, "net", f.name))
+
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				// IPv6 may be disabled, in which case the *6 files are absent
+				continue
+			}
+
+			return nil, err
+		}
+
+		found = true
+		socks, err := ParseProcNet(fd, f.protocol, f.family)
+		fd.Close()
+
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", fd.Name(), err)
+		}
+
+		result = append(result, socks...)
+	}
+
+	if !found {
+		return nil, fmt.Errorf("no socket tables found under %s", filepath.Join(procRoot, "net"))
+	}
+
+	owners := socketOwners(procRoot)
+
+	for _, s := range result {
+		if pid, ok := owners[s.Inode]; ok {
+			s.PID = pid
+			s.Process = processName(procRoot, pid)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+
+		if a.Protocol != b.Protocol {
+			return a.Protocol < b.Protocol
+		}
+
+		if a.Port != b.Port {
+			return a.Port < b.Port
+		}
+
+		if a.Family != b.Family {
+			return a.Family < b.Family
+		}
+
+		return a.Address < b.Address
+	})
+
+	return result, nil
+}
+
+// ParseProcNet parses one of the /proc/net/{tcp,tcp6,udp,udp6} tables,
+// returning only those sockets that are listening for connections or datagrams.
+func ParseProcNet(r io.Reader, protocol, family string) ([]*Socket, error) {
+	result := make([]*Socket, 0, 8)
+	scanner := bufio.NewScanner(r)
+	header := true
+
+	for scanner.Scan() {
+		if header {
+			header = false
+			continue
+		}
+
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) < 10 {
+			continue
+		}
+
+		local, remote, state := fields[1], fields[2], fields[3]
+
+		if protocol == "tcp" && state != tcpListen {
+			continue
+		}
+
+		if protocol == "udp" && state != udpUnconnected {
+			continue
+		}
+
+		ip, port, err := parseHexAddress(local)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if protocol == "udp" {
+			rip, _, err := parseHexAddress(remote)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if !rip.IsUnspecified() {
+				continue
+			}
+		}
+
+		inode, err := strconv.ParseUint(fields[9], 10, 64)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid inode %q: %w", fields[9], err)
+		}
+
+		result = append(result, &Socket{
+			Protocol: protocol,
+			Family:   family,
+			Address:  ip.String(),
+			Port:     port,
+			Inode:    inode,
+		})
+	}
+
+	return result, scanner.Err()
+}
+
+// parseHexAddress decodes an address of the form "0100007F:0277".
+// The kernel writes the address as a sequence of 32 bit words in host byte order,
+// which is assumed here to be little endian as on all mainstream architectures.
+func parseHexAddress(s string) (net.IP, int, error) {
+	addr, portHex, ok := strings.Cut(s, ":")
+
+	if !ok {
+		return nil, 0, fmt.Errorf("invalid socket address %q", s)
+	}
+
+	b, err := hex.DecodeString(addr)
+
+	if err != nil || (len(b) != net.IPv4len && len(b) != net.IPv6len) {
+		return nil, 0, fmt.Errorf("invalid socket address %q", s)
+	}
+
+	for i := 0; i < len(b); i += 4 {
+		b[i], b[i+1], b[i+2], b[i+3] = b[i+3], b[i+2], b[i+1], b[i]
+	}
+
+	port, err := strconv.ParseUint(portHex, 16, 16)
+
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid socket port %q", s)
+	}
+
+	return net.IP(b), int(port), nil
+}
+
+// socketOwners maps socket inodes to the PID holding a descriptor on them.
+// Processes whose descriptors cannot be read are silently ignored.
+func socketOwners(procRoot string) map[uint64]int {
+	owners := make(map[uint64]int)
+	entries, err := os.ReadDir(procRoot)
+
+	if err != nil {
+		return owners
+	}
+
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+
+		if err != nil || !e.IsDir() {
+			continue
+		}
+
+		fdDir := filepath.Join(procRoot, e.Name(), "fd")
+		fds, err := os.ReadDir(fdDir)
+
+		if err != nil {
+			continue
+		}
+
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+
+			if err != nil || !strings.HasPrefix(link, "socket:[") {
+				continue
+			}
+
+			inode, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]"), 10, 64)
+
+			if err != nil {
+				continue
+			}
+
+			if _, ok := owners[inode]; !ok {
+				owners[inode] = pid
+			}
+		}
+	}
+
+	return owners
+}
+
+func processName(procRoot string, pid int) string {
+	b, err := os.ReadFile(filepath.Join(procRoot, strconv.Itoa(pid), "comm"))
+
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(b))
+}
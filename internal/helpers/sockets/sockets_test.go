@@ -0,0 +1,108 @@
+package sockets
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const procNetTcp = `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 0100007F:0CEA 00000000:0000 0A 00000000:00000000 00:00000000 00000000   999        0 22473 1 0000000000000000 100 0 0 10 0
+   1: 0100007F:0CEA 0100007F:C350 01 00000000:00000000 00:00000000 00000000   999        0 22474 1 0000000000000000 20 4 30 10 -1
+`
+
+const procNetTcp6 = `  sl  local_address                         remote_address                        st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000000000000000000001000000:1F90 00000000000000000000000000000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 33001 1 0000000000000000 100 0 0 10 0
+`
+
+const procNetUdp = `   sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ref pointer drops
+  100: 3500007F:0035 00000000:0000 07 00000000:00000000 00:00000000 00000000   101        0 18011 2 0000000000000000 0
+  101: 0F02000A:D431 08080808:0035 01 00000000:00000000 00:00000000 00000000  1000        0 18012 2 0000000000000000 0
+`
+
+func TestParseProcNetTcp(t *testing.T) {
+	socks, err := ParseProcNet(strings.NewReader(procNetTcp), "tcp", "ipv4")
+
+	require.NoError(t, err)
+	require.Len(t, socks, 1)
+	require.Equal(t, "127.0.0.1", socks[0].Address)
+	require.Equal(t, 3306, socks[0].Port)
+	require.Equal(t, uint64(22473), socks[0].Inode)
+}
+
+func TestParseProcNetTcp6(t *testing.T) {
+	socks, err := ParseProcNet(strings.NewReader(procNetTcp6), "tcp", "ipv6")
+
+	require.NoError(t, err)
+	require.Len(t, socks, 1)
+	require.Equal(t, "::1", socks[0].Address)
+	require.Equal(t, 8080, socks[0].Port)
+}
+
+func TestParseProcNetUdpIgnoresConnectedSockets(t *testing.T) {
+	socks, err := ParseProcNet(strings.NewReader(procNetUdp), "udp", "ipv4")
+
+	require.NoError(t, err)
+	require.Len(t, socks, 1)
+	require.Equal(t, "127.0.0.53", socks[0].Address)
+	require.Equal(t, 53, socks[0].Port)
+}
+
+func TestScanAttributesOwningProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Symbolic links to socket inodes cannot be created on Windows")
+	}
+
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "net", "tcp"), procNetTcp)
+	writeFile(t, filepath.Join(root, "net", "udp"), procNetUdp)
+	writeFile(t, filepath.Join(root, "1234", "comm"), "mysqld\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "1234", "fd"), 0o755))
+	require.NoError(t, os.Symlink("socket:[22473]", filepath.Join(root, "1234", "fd", "3")))
+
+	socks, err := Scan(root)
+
+	require.NoError(t, err)
+	require.Len(t, socks, 2)
+	require.Equal(t, "tcp", socks[0].Protocol)
+	require.Equal(t, 1234, socks[0].PID)
+	require.Equal(t, "mysqld", socks[0].Process)
+	require.Equal(t, "udp", socks[1].Protocol)
+	require.Equal(t, 0, socks[1].PID)
+}
+
+func TestScanFindsLiveListener(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skipf("%s has no /proc/net", runtime.GOOS)
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	addr, ok := l.Addr().(*net.TCPAddr)
+	require.True(t, ok)
+
+	port := addr.Port
+	socks, err := Scan(DefaultProcRoot)
+	require.NoError(t, err)
+
+	for _, s := range socks {
+		if s.Protocol == "tcp" && s.Port == port {
+			require.Equal(t, os.Getpid(), s.PID)
+			return
+		}
+	}
+
+	t.Fatalf("listener on port %d not found", port)
+}
+
+func writeFile(t *testing.T, path, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
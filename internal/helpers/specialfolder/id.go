@@ -0,0 +1,26 @@
+package specialfolder
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/machineid"
+)
+
+// machineID identifies the machine by the provider's application-specific
+// machine ID, the same as localos_machine. If that cannot be read, e.g. the
+// fallback ID cannot be saved, it falls back to a hash of the host name.
+func machineID() string {
+	if m, err := machineid.Read(machineid.DefaultPaths()); err == nil {
+		if id, err := machineid.AppSpecific(m.MachineID, machineid.ProviderAppID); err == nil {
+			return id
+		}
+	}
+
+	name, _ := os.Hostname()
+	h := fnv.New32a()
+	h.Write([]byte(name))
+
+	return fmt.Sprintf("%08x", h.Sum32())
+}
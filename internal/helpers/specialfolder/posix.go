@@ -1,8 +1,6 @@
 package specialfolder
 
 import (
-	"fmt"
-	"hash/fnv"
 	"os"
 	"os/user"
 	"path/filepath"
@@ -42,7 +40,5 @@ func (f *posixSpecialFolder) SSH() string {
 }
 
 func (f *posixSpecialFolder) ID() string {
-	h := fnv.New32a()
-	h.Sum([]byte(os.Getenv("HOSTNAME")))
-	return fmt.Sprintf("%08x", h.Sum32())
+	return machineID()
 }
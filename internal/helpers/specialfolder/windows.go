@@ -1,8 +1,6 @@
 package specialfolder
 
 import (
-	"fmt"
-	"hash/fnv"
 	"os"
 	"os/user"
 	"path/filepath"
@@ -40,7 +38,5 @@ func (f *windowsSpecialFolder) SSH() string {
 }
 
 func (f *windowsSpecialFolder) ID() string {
-	h := fnv.New32a()
-	h.Sum([]byte(os.Getenv("COMPUTERNAME")))
-	return fmt.Sprintf("%08x", h.Sum32())
+	return machineID()
 }
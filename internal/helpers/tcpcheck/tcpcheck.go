@@ -0,0 +1,93 @@
+package tcpcheck
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"time"
+)
+
+type Options struct {
+	// Timeout for each connection attempt, including TLS handshake.
+	Timeout time.Duration
+
+	// TLS performs a TLS handshake once the TCP connection is established.
+	TLS bool
+
+	// InsecureSkipVerify disables certificate verification for the TLS handshake.
+	InsecureSkipVerify bool
+}
+
+type Result struct {
+	Target          string
+	Reachable       bool
+	Latency         time.Duration
+	ResolvedAddress string
+	Error           error
+}
+
+// Check attempts to connect to target, which must be of the form host:port.
+// Failure to connect is reported in the result rather than as an error.
+func Check(ctx context.Context, dialer *net.Dialer, target string, opts Options) *Result {
+	result := &Result{
+		Target: target,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	host, _, err := net.SplitHostPort(target)
+
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", target)
+
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	defer conn.Close()
+
+	if opts.TLS {
+		tlsConn := tls.Client(conn, &tls.Config{
+			ServerName:         host,
+			InsecureSkipVerify: opts.InsecureSkipVerify,
+		})
+
+		if err = tlsConn.HandshakeContext(ctx); err != nil {
+			result.ResolvedAddress = conn.RemoteAddr().String()
+			result.Error = err
+			return result
+		}
+	}
+
+	result.Latency = time.Since(start)
+	result.Reachable = true
+	result.ResolvedAddress = conn.RemoteAddr().String()
+
+	return result
+}
+
+// CheckAll checks each target concurrently, returning results in the same order as targets.
+func CheckAll(ctx context.Context, dialer *net.Dialer, targets []string, opts Options) []*Result {
+	results := make([]*Result, len(targets))
+	done := make(chan struct{})
+
+	for i, target := range targets {
+		go func(i int, target string) {
+			results[i] = Check(ctx, dialer, target, opts)
+			done <- struct{}{}
+		}(i, target)
+	}
+
+	for range targets {
+		<-done
+	}
+
+	return results
+}
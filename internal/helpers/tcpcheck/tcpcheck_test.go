@@ -0,0 +1,80 @@
+package tcpcheck
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testOptions = Options{
+	Timeout: 2 * time.Second,
+}
+
+func TestCheckReachable(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	result := Check(context.Background(), &net.Dialer{}, l.Addr().String(), testOptions)
+
+	require.NoError(t, result.Error)
+	require.True(t, result.Reachable)
+	require.Equal(t, l.Addr().String(), result.ResolvedAddress)
+}
+
+func TestCheckUnreachable(t *testing.T) {
+	// Grab a free port, then close it so nothing is listening.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	target := l.Addr().String()
+	l.Close()
+
+	result := Check(context.Background(), &net.Dialer{}, target, testOptions)
+
+	require.Error(t, result.Error)
+	require.False(t, result.Reachable)
+}
+
+func TestCheckInvalidTarget(t *testing.T) {
+	result := Check(context.Background(), &net.Dialer{}, "no-port-here", testOptions)
+
+	require.Error(t, result.Error)
+	require.False(t, result.Reachable)
+}
+
+func TestCheckTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	target := strings.TrimPrefix(server.URL, "https://")
+
+	// Self signed certificate fails verification
+	result := Check(context.Background(), &net.Dialer{}, target, Options{Timeout: 2 * time.Second, TLS: true})
+	require.Error(t, result.Error)
+	require.False(t, result.Reachable)
+
+	result = Check(context.Background(), &net.Dialer{}, target, Options{Timeout: 2 * time.Second, TLS: true, InsecureSkipVerify: true})
+	require.NoError(t, result.Error)
+	require.True(t, result.Reachable)
+}
+
+func TestCheckAllPreservesOrder(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	targets := []string{"bad", l.Addr().String()}
+	results := CheckAll(context.Background(), &net.Dialer{}, targets, testOptions)
+
+	require.Len(t, results, 2)
+	require.Equal(t, "bad", results[0].Target)
+	require.False(t, results[0].Reachable)
+	require.Equal(t, l.Addr().String(), results[1].Target)
+	require.True(t, results[1].Reachable)
+}
@@ -0,0 +1,15 @@
+package uname
+
+import "errors"
+
+// ErrNotSupported is returned by Uname on systems that do not have uname(2), other than Windows.
+var ErrNotSupported = errors.New("uname is not supported on this operating system")
+
+// Utsname holds the fields returned by uname(2).
+type Utsname struct {
+	Sysname  string
+	Nodename string
+	Release  string
+	Version  string
+	Machine  string
+}
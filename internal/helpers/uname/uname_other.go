@@ -0,0 +1,7 @@
+//go:build !unix && !windows
+
+package uname
+
+func Uname() (*Utsname, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,22 @@
+package uname
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUname(t *testing.T) {
+	u, err := Uname()
+
+	if errors.Is(err, ErrNotSupported) {
+		t.Skipf("uname is not supported on %s", runtime.GOOS)
+	}
+
+	require.NoError(t, err)
+	require.NotEmpty(t, u.Sysname)
+	require.NotEmpty(t, u.Release)
+	require.NotEmpty(t, u.Machine)
+}
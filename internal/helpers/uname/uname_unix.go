@@ -0,0 +1,21 @@
+//go:build unix
+
+package uname
+
+import "golang.org/x/sys/unix"
+
+func Uname() (*Utsname, error) {
+	var u unix.Utsname
+
+	if err := unix.Uname(&u); err != nil {
+		return nil, err
+	}
+
+	return &Utsname{
+		Sysname:  unix.ByteSliceToString(u.Sysname[:]),
+		Nodename: unix.ByteSliceToString(u.Nodename[:]),
+		Release:  unix.ByteSliceToString(u.Release[:]),
+		Version:  unix.ByteSliceToString(u.Version[:]),
+		Machine:  unix.ByteSliceToString(u.Machine[:]),
+	}, nil
+}
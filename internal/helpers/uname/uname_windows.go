@@ -0,0 +1,36 @@
+//go:build windows
+
+package uname
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// Uname emulates uname(2) on Windows, in the same way as the uname of
+// Cygwin and MSYS2. Release is the kernel version, e.g. "10.0.22631".
+func Uname() (*Utsname, error) {
+	v := windows.RtlGetVersion()
+	nodename, err := os.Hostname()
+
+	if err != nil {
+		return nil, err
+	}
+
+	// A 32 bit process on a 64 bit system sees the architecture it is emulated on
+	machine := os.Getenv("PROCESSOR_ARCHITEW6432")
+
+	if machine == "" {
+		machine = os.Getenv("PROCESSOR_ARCHITECTURE")
+	}
+
+	return &Utsname{
+		Sysname:  "Windows_NT",
+		Nodename: nodename,
+		Release:  fmt.Sprintf("%d.%d.%d", v.MajorVersion, v.MinorVersion, v.BuildNumber),
+		Version:  windows.UTF16ToString(v.CsdVersion[:]),
+		Machine:  machine,
+	}, nil
+}
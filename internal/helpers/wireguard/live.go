@@ -0,0 +1,79 @@
+package wireguard
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultSocketDir is where userspace implementations create their API sockets.
+const DefaultSocketDir = "/var/run/wireguard"
+
+const uapiTimeout = 2 * time.Second
+
+// Live returns the state of running interfaces, so far as it can be read.
+// Userspace implementations are queried via their API sockets in socketDir.
+// Kernel interfaces are read with "wg show all dump", which requires the wg
+// tool and CAP_NET_ADMIN. Any failure is treated as there being no live state.
+func Live(ctx context.Context, socketDir string) []*Interface {
+	result := make([]*Interface, 0, 2)
+	seen := make(map[string]bool)
+
+	sockets, _ := filepath.Glob(filepath.Join(socketDir, "*.sock"))
+
+	for _, s := range sockets {
+		if ifc, err := queryUAPI(s); err == nil {
+			ifc.Name = strings.TrimSuffix(filepath.Base(s), ".sock")
+			seen[ifc.Name] = true
+			result = append(result, ifc)
+		}
+	}
+
+	if _, err := exec.LookPath("wg"); err != nil {
+		return result
+	}
+
+	out, err := exec.CommandContext(ctx, "wg", "show", "all", "dump").Output()
+
+	if err != nil {
+		return result
+	}
+
+	dumped, err := ParseDump(bytes.NewReader(out))
+
+	if err != nil {
+		return result
+	}
+
+	for _, ifc := range dumped {
+		if !seen[ifc.Name] {
+			result = append(result, ifc)
+		}
+	}
+
+	return result
+}
+
+func queryUAPI(path string) (*Interface, error) {
+	conn, err := net.DialTimeout("unix", path, uapiTimeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	if err = conn.SetDeadline(time.Now().Add(uapiTimeout)); err != nil {
+		return nil, err
+	}
+
+	if _, err = conn.Write([]byte("get=1\n\n")); err != nil {
+		return nil, err
+	}
+
+	return ParseUAPI(conn)
+}
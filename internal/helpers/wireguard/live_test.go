@@ -0,0 +1,63 @@
+package wireguard
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiveFromUAPISocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Userspace API sockets are not used on Windows")
+	}
+
+	// Unix socket paths are limited in length, so avoid the long test temp dir
+	dir, err := os.MkdirTemp("", "wg")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	l, err := net.Listen("unix", filepath.Join(dir, "utun7.sock"))
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		conn, err := l.Accept()
+
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		// Read the request up to the terminating blank line
+		r := bufio.NewReader(conn)
+
+		for {
+			line, err := r.ReadString('\n')
+
+			if err != nil || line == "\n" {
+				break
+			}
+		}
+
+		_, _ = conn.Write([]byte("listen_port=51820\npublic_key=de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882b4f\nallowed_ip=10.0.0.0/8\nerrno=0\n\n"))
+	}()
+
+	var found *Interface
+
+	for _, ifc := range Live(context.Background(), dir) {
+		if ifc.Name == "utun7" {
+			found = ifc
+		}
+	}
+
+	require.NotNil(t, found)
+	require.Equal(t, 51820, found.ListenPort)
+	require.Equal(t, bobPublic, found.Peers[0].PublicKey)
+}
@@ -0,0 +1,418 @@
+package wireguard
+
+import (
+	"bufio"
+	"crypto/ecdh"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultConfigDir is where wg-quick looks for interface configurations.
+const DefaultConfigDir = "/etc/wireguard"
+
+const keyLength = 32
+
+type Interface struct {
+	Name       string
+	ConfigPath string
+
+	// PublicKey is derived from the private key, which is never retained
+	PublicKey  string
+	Addresses  []string
+	DNS        []string
+	ListenPort int
+	MTU        int
+
+	// Up is true if live state was read for the interface
+	Up    bool
+	Peers []*Peer
+}
+
+type Peer struct {
+	PublicKey           string
+	Endpoint            string
+	AllowedIPs          []string
+	PersistentKeepalive int
+
+	// Only known from live state
+	LatestHandshake time.Time
+	RxBytes         int64
+	TxBytes         int64
+}
+
+// PublicKey derives the base64 encoded public key from a base64 encoded private key.
+func PublicKey(privateKey string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(privateKey)
+
+	if err != nil || len(b) != keyLength {
+		return "", fmt.Errorf("invalid private key")
+	}
+
+	return publicKeyFromBytes(b)
+}
+
+func publicKeyFromBytes(b []byte) (string, error) {
+	key, err := ecdh.X25519().NewPrivateKey(b)
+
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(key.PublicKey().Bytes()), nil
+}
+
+// ReadConfigDir parses each *.conf file in dir. Interfaces are named after the file.
+// Files that cannot be read or parsed are reported in errs, and do not prevent
+// the others being returned.
+func ReadConfigDir(dir string) (interfaces []*Interface, errs []error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.conf"))
+
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	interfaces = make([]*Interface, 0, len(files))
+
+	for _, path := range files {
+		ifc, err := readConfig(path)
+
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		interfaces = append(interfaces, ifc)
+	}
+
+	return interfaces, errs
+}
+
+func readConfig(path string) (*Interface, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	ifc, err := ParseConfig(f)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	ifc.Name = strings.TrimSuffix(filepath.Base(path), ".conf")
+	ifc.ConfigPath = path
+	return ifc, nil
+}
+
+// ParseConfig parses a wg-quick configuration file.
+func ParseConfig(r io.Reader) (*Interface, error) {
+	ifc := &Interface{}
+	scanner := bufio.NewScanner(r)
+	section := ""
+	lineNo := 0
+
+	var peer *Peer
+
+	for scanner.Scan() {
+		lineNo++
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		line = strings.TrimSpace(line)
+
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.ToLower(strings.TrimSpace(line[1 : len(line)-1]))
+
+			if section == "peer" {
+				peer = &Peer{}
+				ifc.Peers = append(ifc.Peers, peer)
+			}
+
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNo)
+		}
+
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		var err error
+
+		switch section {
+		case "interface":
+			switch key {
+			case "privatekey":
+				ifc.PublicKey, err = PublicKey(value)
+			case "address":
+				ifc.Addresses = append(ifc.Addresses, splitList(value)...)
+			case "dns":
+				ifc.DNS = append(ifc.DNS, splitList(value)...)
+			case "listenport":
+				ifc.ListenPort, err = strconv.Atoi(value)
+			case "mtu":
+				ifc.MTU, err = strconv.Atoi(value)
+			}
+		case "peer":
+			switch key {
+			case "publickey":
+				peer.PublicKey = value
+			case "endpoint":
+				peer.Endpoint = value
+			case "allowedips":
+				peer.AllowedIPs = append(peer.AllowedIPs, splitList(value)...)
+			case "persistentkeepalive":
+				if value != "off" {
+					peer.PersistentKeepalive, err = strconv.Atoi(value)
+				}
+			}
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid %s: %w", lineNo, key, err)
+		}
+	}
+
+	return ifc, scanner.Err()
+}
+
+// Merge overlays live state onto configurations, matching interfaces by name and
+// peers by public key. Interfaces and peers only present in live state are added.
+// The result is sorted by interface name.
+func Merge(configured, live []*Interface) []*Interface {
+	byName := make(map[string]*Interface, len(configured))
+	result := make([]*Interface, 0, len(configured)+len(live))
+
+	for _, c := range configured {
+		byName[c.Name] = c
+		result = append(result, c)
+	}
+
+	for _, l := range live {
+		c, ok := byName[l.Name]
+
+		if !ok {
+			result = append(result, l)
+			continue
+		}
+
+		c.Up = true
+		c.ListenPort = l.ListenPort
+
+		if l.PublicKey != "" {
+			c.PublicKey = l.PublicKey
+		}
+
+		peers := make(map[string]*Peer, len(c.Peers))
+
+		for _, p := range c.Peers {
+			peers[p.PublicKey] = p
+		}
+
+		for _, lp := range l.Peers {
+			p, ok := peers[lp.PublicKey]
+
+			if !ok {
+				c.Peers = append(c.Peers, lp)
+				continue
+			}
+
+			// Endpoint may have roamed, or been learned from the peer
+			if lp.Endpoint != "" {
+				p.Endpoint = lp.Endpoint
+			}
+
+			p.LatestHandshake = lp.LatestHandshake
+			p.RxBytes = lp.RxBytes
+			p.TxBytes = lp.TxBytes
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// ParseUAPI parses the response to a "get=1" request on the userspace API socket
+// of implementations such as wireguard-go. Keys in this protocol are hex encoded.
+func ParseUAPI(r io.Reader) (*Interface, error) {
+	ifc := &Interface{Up: true}
+	scanner := bufio.NewScanner(r)
+
+	var peer *Peer
+	var handshakeSec, handshakeNsec int64
+
+	endPeer := func() {
+		if peer != nil && handshakeSec != 0 {
+			peer.LatestHandshake = time.Unix(handshakeSec, handshakeNsec).UTC()
+		}
+
+		handshakeSec, handshakeNsec = 0, 0
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line == "" {
+			break
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+
+		if !ok {
+			continue
+		}
+
+		var err error
+
+		switch key {
+		case "errno":
+			if value != "0" {
+				return nil, fmt.Errorf("device returned errno %s", value)
+			}
+		case "private_key":
+			var b []byte
+
+			if b, err = hex.DecodeString(value); err == nil {
+				ifc.PublicKey, err = publicKeyFromBytes(b)
+			}
+		case "listen_port":
+			ifc.ListenPort, err = strconv.Atoi(value)
+		case "public_key":
+			// Starts a new peer
+			endPeer()
+
+			var b []byte
+
+			if b, err = hex.DecodeString(value); err == nil {
+				peer = &Peer{PublicKey: base64.StdEncoding.EncodeToString(b)}
+				ifc.Peers = append(ifc.Peers, peer)
+			}
+		default:
+			if peer != nil {
+				err = setPeerValue(peer, key, value, &handshakeSec, &handshakeNsec)
+			}
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+
+	endPeer()
+	return ifc, scanner.Err()
+}
+
+func setPeerValue(peer *Peer, key, value string, handshakeSec, handshakeNsec *int64) (err error) {
+	switch key {
+	case "endpoint":
+		peer.Endpoint = value
+	case "allowed_ip":
+		peer.AllowedIPs = append(peer.AllowedIPs, value)
+	case "persistent_keepalive_interval":
+		peer.PersistentKeepalive, err = strconv.Atoi(value)
+	case "last_handshake_time_sec":
+		*handshakeSec, err = strconv.ParseInt(value, 10, 64)
+	case "last_handshake_time_nsec":
+		*handshakeNsec, err = strconv.ParseInt(value, 10, 64)
+	case "rx_bytes":
+		peer.RxBytes, err = strconv.ParseInt(value, 10, 64)
+	case "tx_bytes":
+		peer.TxBytes, err = strconv.ParseInt(value, 10, 64)
+	}
+
+	return err
+}
+
+// ParseDump parses the output of "wg show all dump".
+func ParseDump(r io.Reader) ([]*Interface, error) {
+	result := make([]*Interface, 0, 2)
+	byName := make(map[string]*Interface)
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+
+		switch len(fields) {
+		case 5:
+			// interface, private-key, public-key, listen-port, fwmark
+			ifc := &Interface{Name: fields[0], PublicKey: none(fields[2]), Up: true}
+			ifc.ListenPort, _ = strconv.Atoi(fields[3])
+			byName[ifc.Name] = ifc
+			result = append(result, ifc)
+
+		case 9:
+			// interface, public-key, preshared-key, endpoint, allowed-ips,
+			// latest-handshake, transfer-rx, transfer-tx, persistent-keepalive
+			ifc, ok := byName[fields[0]]
+
+			if !ok {
+				return nil, fmt.Errorf("peer of unknown interface %s", fields[0])
+			}
+
+			p := &Peer{
+				PublicKey: fields[1],
+				Endpoint:  none(fields[3]),
+			}
+
+			if allowed := none(fields[4]); allowed != "" {
+				p.AllowedIPs = splitList(allowed)
+			}
+
+			if sec, _ := strconv.ParseInt(fields[5], 10, 64); sec != 0 {
+				p.LatestHandshake = time.Unix(sec, 0).UTC()
+			}
+
+			p.RxBytes, _ = strconv.ParseInt(fields[6], 10, 64)
+			p.TxBytes, _ = strconv.ParseInt(fields[7], 10, 64)
+			p.PersistentKeepalive, _ = strconv.Atoi(fields[8])
+			ifc.Peers = append(ifc.Peers, p)
+
+		case 1:
+			// Blank line
+			continue
+
+		default:
+			return nil, fmt.Errorf("unexpected line in dump: %q", scanner.Text())
+		}
+	}
+
+	return result, scanner.Err()
+}
+
+func none(s string) string {
+	if s == "(none)" {
+		return ""
+	}
+
+	return s
+}
+
+func splitList(s string) []string {
+	result := make([]string, 0, 2)
+
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,162 @@
+package wireguard
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Key pairs from RFC 7748 section 6.1
+const (
+	alicePrivate = "dwdtCnMYpX08FsFyUbJmRd9ML4frwJkqsXf7pR25LCo="
+	alicePublic  = "hSDwCYkwp1R0i33ctD73Wg2/Og0mOBr066SpjqqbTmo="
+	bobPublic    = "3p7bfXt9wbTTW2HC7OQ1Nz+DQ8hbeGdNrfx+FG+IK08="
+)
+
+const wg0Conf = `[Interface]
+# laptop
+PrivateKey = ` + alicePrivate + `
+Address = 10.100.0.2/32, fd00:100::2/128
+DNS = 10.100.0.1
+ListenPort = 51820
+
+[Peer]
+PublicKey = ` + bobPublic + `
+PresharedKey = AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=
+AllowedIPs = 10.100.0.0/24
+AllowedIPs = 10.200.0.0/16
+Endpoint = vpn.example.com:51820
+PersistentKeepalive = 25
+`
+
+func TestPublicKey(t *testing.T) {
+	pub, err := PublicKey(alicePrivate)
+
+	require.NoError(t, err)
+	require.Equal(t, alicePublic, pub)
+
+	_, err = PublicKey("not a key")
+	require.Error(t, err)
+}
+
+func TestParseConfig(t *testing.T) {
+	ifc, err := ParseConfig(strings.NewReader(wg0Conf))
+
+	require.NoError(t, err)
+	require.Equal(t, alicePublic, ifc.PublicKey)
+	require.Equal(t, []string{"10.100.0.2/32", "fd00:100::2/128"}, ifc.Addresses)
+	require.Equal(t, []string{"10.100.0.1"}, ifc.DNS)
+	require.Equal(t, 51820, ifc.ListenPort)
+	require.False(t, ifc.Up)
+	require.Len(t, ifc.Peers, 1)
+	require.Equal(t, &Peer{
+		PublicKey:           bobPublic,
+		Endpoint:            "vpn.example.com:51820",
+		AllowedIPs:          []string{"10.100.0.0/24", "10.200.0.0/16"},
+		PersistentKeepalive: 25,
+	}, ifc.Peers[0])
+}
+
+func TestParseConfigInvalid(t *testing.T) {
+	_, err := ParseConfig(strings.NewReader("[Interface]\nListenPort = many\n"))
+
+	require.ErrorContains(t, err, "line 2")
+}
+
+func TestReadConfigDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "wg0.conf"), []byte(wg0Conf), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bad.conf"), []byte("[Interface]\nPrivateKey = x\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o600))
+
+	interfaces, errs := ReadConfigDir(dir)
+
+	require.Len(t, interfaces, 1)
+	require.Equal(t, "wg0", interfaces[0].Name)
+	require.Equal(t, filepath.Join(dir, "wg0.conf"), interfaces[0].ConfigPath)
+	require.Len(t, errs, 1)
+	require.ErrorContains(t, errs[0], "bad.conf")
+}
+
+func TestParseUAPI(t *testing.T) {
+	ifc, err := ParseUAPI(strings.NewReader(`private_key=77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a
+listen_port=51821
+public_key=de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882b4f
+preshared_key=0000000000000000000000000000000000000000000000000000000000000000
+endpoint=203.0.113.5:51820
+last_handshake_time_sec=1697500000
+last_handshake_time_nsec=0
+tx_bytes=100
+rx_bytes=200
+persistent_keepalive_interval=0
+allowed_ip=10.100.0.0/24
+errno=0
+
+`))
+
+	require.NoError(t, err)
+	require.True(t, ifc.Up)
+	require.Equal(t, alicePublic, ifc.PublicKey)
+	require.Equal(t, 51821, ifc.ListenPort)
+	require.Len(t, ifc.Peers, 1)
+	require.Equal(t, bobPublic, ifc.Peers[0].PublicKey)
+	require.Equal(t, "203.0.113.5:51820", ifc.Peers[0].Endpoint)
+	require.Equal(t, time.Unix(1697500000, 0).UTC(), ifc.Peers[0].LatestHandshake)
+	require.Equal(t, int64(200), ifc.Peers[0].RxBytes)
+	require.Equal(t, []string{"10.100.0.0/24"}, ifc.Peers[0].AllowedIPs)
+}
+
+func TestParseDump(t *testing.T) {
+	interfaces, err := ParseDump(strings.NewReader(
+		"wg0\t" + alicePrivate + "\t" + alicePublic + "\t51820\toff\n" +
+			"wg0\t" + bobPublic + "\t(none)\t203.0.113.5:51820\t10.100.0.0/24,10.200.0.0/16\t1697500000\t200\t100\t25\n" +
+			"wg0\tXasIfmJKikt54X+Lg4AO5m87sSkmGLb9HC+LJ/+I4Os=\t(none)\t(none)\t(none)\t0\t0\t0\toff\n",
+	))
+
+	require.NoError(t, err)
+	require.Len(t, interfaces, 1)
+	require.Equal(t, alicePublic, interfaces[0].PublicKey)
+	require.Len(t, interfaces[0].Peers, 2)
+	require.Equal(t, []string{"10.100.0.0/24", "10.200.0.0/16"}, interfaces[0].Peers[0].AllowedIPs)
+	require.Equal(t, 25, interfaces[0].Peers[0].PersistentKeepalive)
+	require.Empty(t, interfaces[0].Peers[1].Endpoint)
+	require.Nil(t, interfaces[0].Peers[1].AllowedIPs)
+	require.True(t, interfaces[0].Peers[1].LatestHandshake.IsZero())
+}
+
+func TestMerge(t *testing.T) {
+	configured, err := ParseConfig(strings.NewReader(wg0Conf))
+	require.NoError(t, err)
+	configured.Name = "wg0"
+
+	live := []*Interface{
+		{
+			Name:       "wg0",
+			Up:         true,
+			ListenPort: 40000,
+			Peers: []*Peer{
+				{PublicKey: bobPublic, Endpoint: "203.0.113.5:51820", LatestHandshake: time.Unix(1697500000, 0), RxBytes: 1},
+				{PublicKey: "XasIfmJKikt54X+Lg4AO5m87sSkmGLb9HC+LJ/+I4Os="},
+			},
+		},
+		{Name: "wg-live", Up: true},
+	}
+
+	merged := Merge([]*Interface{configured}, live)
+
+	require.Len(t, merged, 2)
+	require.Equal(t, "wg-live", merged[0].Name)
+
+	wg0 := merged[1]
+	require.True(t, wg0.Up)
+	require.Equal(t, 40000, wg0.ListenPort)
+	require.Equal(t, alicePublic, wg0.PublicKey)
+	require.Len(t, wg0.Peers, 2)
+	require.Equal(t, "203.0.113.5:51820", wg0.Peers[0].Endpoint)
+	require.Equal(t, []string{"10.100.0.0/24", "10.200.0.0/16"}, wg0.Peers[0].AllowedIPs)
+	require.Equal(t, int64(1), wg0.Peers[0].RxBytes)
+}
@@ -0,0 +1,189 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/cgroup"
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/cpuinfo"
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/meminfo"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CgroupDataSource{}
+
+func NewCgroupDataSource() datasource.DataSource {
+	return &CgroupDataSource{}
+}
+
+// CgroupDataSource defines the data source implementation.
+type CgroupDataSource struct {
+}
+
+// CgroupDataSourceModel describes the data source data model.
+type CgroupDataSourceModel struct {
+	Id                   types.String  `tfsdk:"id"`
+	Version              types.Int64   `tfsdk:"version"`
+	Path                 types.String  `tfsdk:"path"`
+	CpuQuotaUs           types.Int64   `tfsdk:"cpu_quota_us"`
+	CpuPeriodUs          types.Int64   `tfsdk:"cpu_period_us"`
+	MemoryMaxBytes       types.Int64   `tfsdk:"memory_max_bytes"`
+	MemoryHighBytes      types.Int64   `tfsdk:"memory_high_bytes"`
+	PidsMax              types.Int64   `tfsdk:"pids_max"`
+	CpuLimit             types.Float64 `tfsdk:"cpu_limit"`
+	MemoryLimitBytes     types.Int64   `tfsdk:"memory_limit_bytes"`
+	EffectiveCpus        types.Float64 `tfsdk:"effective_cpus"`
+	EffectiveMemoryBytes types.Int64   `tfsdk:"effective_memory_bytes"`
+}
+
+func (d *CgroupDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cgroup"
+}
+
+func (d *CgroupDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "The `cgroup` data source gets the CPU, memory and process limits of the cgroup terraform is running in, " +
+			"from cgroup v1 or v2, and the CPUs and memory that terraform can actually use. " +
+			"In a container, these are the limits of the container rather than the size of the host. Limits are only available on Linux.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier",
+				Computed:            true,
+			},
+			"version": schema.Int64Attribute{
+				MarkdownDescription: "`2` if the CPU and memory controllers are in the unified cgroup v2 hierarchy, otherwise `1`.",
+				Computed:            true,
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Cgroup of terraform, e.g. `/user.slice/user-1000.slice/session-2.scope`, or `/` in a container with its own cgroup namespace. " +
+					"With cgroup v1, the cgroup in the memory hierarchy.",
+				Computed: true,
+			},
+			"cpu_quota_us": schema.Int64Attribute{
+				MarkdownDescription: "CPU time in microseconds the cgroup may use in each period, from `cpu.max` or `cpu.cfs_quota_us`. Null if there is no limit.",
+				Computed:            true,
+			},
+			"cpu_period_us": schema.Int64Attribute{
+				MarkdownDescription: "Length of the period of `cpu_quota_us` in microseconds, usually 100000.",
+				Computed:            true,
+			},
+			"memory_max_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Memory limit of the cgroup, from `memory.max` or `memory.limit_in_bytes`. Null if there is no limit.",
+				Computed:            true,
+			},
+			"memory_high_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Memory above which the cgroup is throttled, from `memory.high`. Null if there is no limit, and with cgroup v1.",
+				Computed:            true,
+			},
+			"pids_max": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of processes and threads, from `pids.max`. Null if there is no limit.",
+				Computed:            true,
+			},
+			"cpu_limit": schema.Float64Attribute{
+				MarkdownDescription: "Lowest CPU limit of the cgroup and its parents, in CPUs, e.g. `1.5`. Null if there is no limit.",
+				Computed:            true,
+			},
+			"memory_limit_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Lowest memory limit of the cgroup and its parents. Null if there is no limit.",
+				Computed:            true,
+			},
+			"effective_cpus": schema.Float64Attribute{
+				MarkdownDescription: "CPUs terraform can use: the lower of `cpu_limit` and the number of CPUs it is allowed to run on. Use `floor()` to get a parallelism.",
+				Computed:            true,
+			},
+			"effective_memory_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Memory terraform can use: the lower of `memory_limit_bytes` and the physical memory. Null on systems other than Linux and macOS.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CgroupDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Nothing to configure
+}
+
+func (d *CgroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CgroupDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limits := &cgroup.Limits{}
+
+	if runtime.GOOS != "linux" {
+		resp.Diagnostics.AddWarning("Cgroups are not available", fmt.Sprintf("Reading cgroups is not supported on %s", runtime.GOOS))
+	} else if l, err := cgroup.Read(cgroup.DefaultProcRoot); err != nil {
+		resp.Diagnostics.AddWarning("Unable to read cgroup limits", err.Error())
+	} else {
+		limits = l
+	}
+
+	data.Id = types.StringValue("cgroup")
+	data.Version = optionalInt64(int64(limits.Version))
+	data.Path = optionalString(limits.Path)
+	data.CpuQuotaUs = optionalInt64(limits.CPUQuota)
+	data.CpuPeriodUs = optionalInt64(limits.CPUPeriod)
+	data.MemoryMaxBytes = optionalInt64(limits.MemoryMax)
+	data.MemoryHighBytes = optionalInt64(limits.MemoryHigh)
+	data.PidsMax = optionalInt64(limits.PidsMax)
+	data.CpuLimit = optionalFloat64(limits.EffectiveCPUs)
+	data.MemoryLimitBytes = optionalInt64(limits.EffectiveMemory)
+
+	cpus := float64(runtime.NumCPU())
+
+	if set, err := cpuinfo.Affinity(); err == nil && len(set) > 0 {
+		cpus = float64(len(set))
+	}
+
+	if limits.EffectiveCPUs > 0 && limits.EffectiveCPUs < cpus {
+		cpus = limits.EffectiveCPUs
+	}
+
+	data.EffectiveCpus = types.Float64Value(cpus)
+	data.EffectiveMemoryBytes = types.Int64Null()
+
+	if total := physicalMemory(); total > 0 {
+		if limits.EffectiveMemory > 0 && limits.EffectiveMemory < total {
+			total = limits.EffectiveMemory
+		}
+
+		data.EffectiveMemoryBytes = types.Int64Value(total)
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Read cgroup data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// physicalMemory returns the size of memory in bytes, or 0 if it cannot be read.
+func physicalMemory() int64 {
+	if runtime.GOOS == "linux" {
+		if info, err := meminfo.Read(meminfo.DefaultProcRoot); err == nil {
+			return int64(info.Total)
+		}
+
+		return 0
+	}
+
+	total, _ := meminfo.Total()
+
+	return int64(total)
+}
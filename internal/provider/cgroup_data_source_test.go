@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCgroupDataSource(t *testing.T) {
+	checks := []resource.TestCheckFunc{
+		resource.TestCheckResourceAttr("data.localos_cgroup.test", "id", "cgroup"),
+		resource.TestCheckResourceAttrSet("data.localos_cgroup.test", "effective_cpus"),
+	}
+
+	if runtime.GOOS == "linux" {
+		checks = append(checks,
+			resource.TestCheckResourceAttrSet("data.localos_cgroup.test", "version"),
+			resource.TestCheckResourceAttrSet("data.localos_cgroup.test", "path"),
+			resource.TestCheckResourceAttrSet("data.localos_cgroup.test", "effective_memory_bytes"),
+		)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: `data "localos_cgroup" "test" {}`,
+				Check:  resource.ComposeAggregateTestCheckFunc(checks...),
+			},
+		},
+	})
+}
@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"os"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/cienv"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CiDataSource{}
+
+func NewCiDataSource() datasource.DataSource {
+	return &CiDataSource{}
+}
+
+// CiDataSource defines the data source implementation.
+type CiDataSource struct {
+}
+
+// CiDataSourceModel describes the data source data model.
+type CiDataSourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	IsCi              types.Bool   `tfsdk:"is_ci"`
+	Provider          types.String `tfsdk:"provider_name"`
+	Repository        types.String `tfsdk:"repository"`
+	Branch            types.String `tfsdk:"branch"`
+	CommitSha         types.String `tfsdk:"commit_sha"`
+	PullRequestNumber types.Int64  `tfsdk:"pull_request_number"`
+	JobUrl            types.String `tfsdk:"job_url"`
+	Actor             types.String `tfsdk:"actor"`
+}
+
+func (d *CiDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ci"
+}
+
+func (d *CiDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "The `ci` data source detects the CI/CD system terraform is running in from its environment variables, " +
+			"and gets the repository, branch, commit, pull request, job and user of the build in the same form for all systems. " +
+			"Attributes that the system does not provide are null.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier",
+				Computed:            true,
+			},
+			"is_ci": schema.BoolAttribute{
+				MarkdownDescription: "True if running in a CI system, including those not detected that set `CI`.",
+				Computed:            true,
+			},
+			"provider_name": schema.StringAttribute{
+				MarkdownDescription: "CI system: `github_actions`, `gitlab`, `azure_pipelines`, `circleci`, `buildkite`, `bitbucket`, `jenkins`, `teamcity`, " +
+					"`atlantis` or `terraform_cloud`. Null if not in CI, or the system is not known.",
+				Computed: true,
+			},
+			"repository": schema.StringAttribute{
+				MarkdownDescription: "Repository being built, usually `owner/name`. Jenkins and Buildkite give the clone URL, Azure Pipelines just the name.",
+				Computed:            true,
+			},
+			"branch": schema.StringAttribute{
+				MarkdownDescription: "Branch being built. For a pull request, the source branch.",
+				Computed:            true,
+			},
+			"commit_sha": schema.StringAttribute{
+				MarkdownDescription: "Commit being built.",
+				Computed:            true,
+			},
+			"pull_request_number": schema.Int64Attribute{
+				MarkdownDescription: "Number of the pull or merge request being built. Null if the build is not of a pull request.",
+				Computed:            true,
+			},
+			"job_url": schema.StringAttribute{
+				MarkdownDescription: "Link to the job, pipeline or build. For Atlantis, the pull request.",
+				Computed:            true,
+			},
+			"actor": schema.StringAttribute{
+				MarkdownDescription: "User who started the build. Bitbucket gives only the UUID of the user.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CiDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Nothing to configure
+}
+
+func (d *CiDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CiDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	build := cienv.Detect(os.Getenv)
+
+	data.Id = types.StringValue("ci")
+	data.IsCi = types.BoolValue(build.IsCI)
+	data.Provider = optionalString(build.Provider)
+	data.Repository = optionalString(build.Repository)
+	data.Branch = optionalString(build.Branch)
+	data.CommitSha = optionalString(build.CommitSHA)
+	data.PullRequestNumber = optionalInt64(int64(build.PullRequest))
+	data.JobUrl = optionalString(build.JobURL)
+	data.Actor = optionalString(build.Actor)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Read ci data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCiDataSource(t *testing.T) {
+	for k, v := range map[string]string{
+		"GITHUB_ACTIONS":    "true",
+		"GITHUB_REPOSITORY": "octo/infra",
+		"GITHUB_REF":        "refs/pull/42/merge",
+		"GITHUB_REF_NAME":   "42/merge",
+		"GITHUB_REF_TYPE":   "branch",
+		"GITHUB_HEAD_REF":   "feature/dns",
+		"GITHUB_SHA":        "4f3c2b1",
+		"GITHUB_ACTOR":      "octocat",
+		"GITHUB_SERVER_URL": "https://github.com",
+		"GITHUB_RUN_ID":     "1658821493",
+	} {
+		t.Setenv(k, v)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: `data "localos_ci" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.localos_ci.test", "id", "ci"),
+					resource.TestCheckResourceAttr("data.localos_ci.test", "is_ci", "true"),
+					resource.TestCheckResourceAttr("data.localos_ci.test", "provider_name", "github_actions"),
+					resource.TestCheckResourceAttr("data.localos_ci.test", "repository", "octo/infra"),
+					resource.TestCheckResourceAttr("data.localos_ci.test", "branch", "feature/dns"),
+					resource.TestCheckResourceAttr("data.localos_ci.test", "commit_sha", "4f3c2b1"),
+					resource.TestCheckResourceAttr("data.localos_ci.test", "pull_request_number", "42"),
+					resource.TestCheckResourceAttr("data.localos_ci.test", "job_url", "https://github.com/octo/infra/actions/runs/1658821493"),
+					resource.TestCheckResourceAttr("data.localos_ci.test", "actor", "octocat"),
+				),
+			},
+		},
+	})
+}
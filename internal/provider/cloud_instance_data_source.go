@@ -0,0 +1,226 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/cloudmeta"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const defaultCloudInstanceTimeout = "2s"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CloudInstanceDataSource{}
+
+func NewCloudInstanceDataSource() datasource.DataSource {
+	return &CloudInstanceDataSource{}
+}
+
+// CloudInstanceDataSource defines the data source implementation.
+type CloudInstanceDataSource struct {
+	client *http.Client
+}
+
+// CloudInstanceDataSourceModel describes the data source data model.
+type CloudInstanceDataSourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	Providers    types.List   `tfsdk:"providers"`
+	Endpoints    types.Map    `tfsdk:"endpoints"`
+	Timeout      types.String `tfsdk:"timeout"`
+	IsCloud      types.Bool   `tfsdk:"is_cloud"`
+	Provider     types.String `tfsdk:"provider_name"`
+	InstanceId   types.String `tfsdk:"instance_id"`
+	Name         types.String `tfsdk:"name"`
+	Region       types.String `tfsdk:"region"`
+	Zone         types.String `tfsdk:"zone"`
+	AccountId    types.String `tfsdk:"account_id"`
+	InstanceType types.String `tfsdk:"instance_type"`
+	PrivateIp    types.String `tfsdk:"private_ip"`
+	PublicIp     types.String `tfsdk:"public_ip"`
+}
+
+func (d *CloudInstanceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_instance"
+}
+
+func (d *CloudInstanceDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "The `cloud_instance` data source gets the identity, location and addresses of the cloud VM that is running terraform " +
+			"from the metadata service of AWS EC2, Google Compute Engine, Azure, Oracle Cloud or DigitalOcean. " +
+			"If terraform is not running on a cloud VM, `is_cloud` is false and the other attributes are null.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier",
+				Computed:            true,
+			},
+			"providers": schema.ListAttribute{
+				MarkdownDescription: "Clouds whose metadata services are queried, from `aws`, `gcp`, `azure`, `oci` and `digitalocean`. Default all. " +
+					"If more than one answers, the first in this list is used.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"endpoints": schema.MapAttribute{
+				MarkdownDescription: "Base URLs of the metadata services, with key=cloud, value=URL, e.g. `{ aws = \"http://localhost:1338\" }` to use a local emulator. " +
+					"Default `http://169.254.169.254`, or `http://metadata.google.internal` for `gcp`.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"timeout": schema.StringAttribute{
+				MarkdownDescription: "Time to wait for the metadata services as a Go duration string. They are queried at once, so this is the most the data source takes. " +
+					"Default `" + defaultCloudInstanceTimeout + "`.",
+				Optional: true,
+			},
+			"is_cloud": schema.BoolAttribute{
+				MarkdownDescription: "True if a metadata service answered.",
+				Computed:            true,
+			},
+			"provider_name": schema.StringAttribute{
+				MarkdownDescription: "Cloud of the VM: `aws`, `gcp`, `azure`, `oci` or `digitalocean`.",
+				Computed:            true,
+			},
+			"instance_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the VM, e.g. `i-0123456789abcdef0`. For Azure, the VM ID (a UUID); for OCI, the OCID; for DigitalOcean, the droplet ID.",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the VM. For AWS, the `Name` tag, if tags are enabled in the instance metadata.",
+				Computed:            true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region, e.g. `eu-west-2`, `us-central1`, `westeurope`, `us-ashburn-1` or `nyc3`.",
+				Computed:            true,
+			},
+			"zone": schema.StringAttribute{
+				MarkdownDescription: "Availability zone, e.g. `eu-west-2a`, `us-central1-a`, `1`, or for OCI the availability domain. Null for DigitalOcean, and for Azure VMs not in a zone.",
+				Computed:            true,
+			},
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "AWS account ID, GCP project ID, Azure subscription ID or OCI compartment OCID. Null for DigitalOcean.",
+				Computed:            true,
+			},
+			"instance_type": schema.StringAttribute{
+				MarkdownDescription: "Instance type, machine type, VM size or shape, e.g. `t3.micro`. Null for DigitalOcean.",
+				Computed:            true,
+			},
+			"private_ip": schema.StringAttribute{
+				MarkdownDescription: "Private IPv4 address of the primary network interface.",
+				Computed:            true,
+			},
+			"public_ip": schema.StringAttribute{
+				MarkdownDescription: "Public IPv4 address of the primary network interface. Null if the VM has none, and for OCI, which does not report it.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CloudInstanceDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Metadata services are reached directly, without the proxies the provider's HTTP client would use
+	d.client = cloudmeta.NewHTTPClient()
+}
+
+func (d *CloudInstanceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CloudInstanceDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providers := cloudmeta.Providers
+	endpoints := map[string]string{}
+
+	if !data.Providers.IsNull() {
+		resp.Diagnostics.Append(data.Providers.ElementsAs(ctx, &providers, false)...)
+	}
+
+	resp.Diagnostics.Append(data.Endpoints.ElementsAs(ctx, &endpoints, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, p := range providers {
+		if _, ok := cloudmeta.DefaultEndpoints[p]; !ok {
+			resp.Diagnostics.AddAttributeError(path.Root("providers"), "Invalid provider",
+				fmt.Sprintf("Provider must be one of %s, got %q", strings.Join(cloudmeta.Providers, ", "), p))
+		}
+	}
+
+	for p, url := range endpoints {
+		if _, ok := cloudmeta.DefaultEndpoints[p]; !ok {
+			resp.Diagnostics.AddAttributeError(path.Root("endpoints").AtMapKey(p), "Invalid provider",
+				fmt.Sprintf("Provider must be one of %s, got %q", strings.Join(cloudmeta.Providers, ", "), p))
+		} else if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			resp.Diagnostics.AddAttributeError(path.Root("endpoints").AtMapKey(p), "Invalid endpoint",
+				fmt.Sprintf("Endpoint must be an http or https URL, got %q", url))
+		}
+	}
+
+	timeoutString := defaultCloudInstanceTimeout
+
+	if !data.Timeout.IsNull() {
+		timeoutString = data.Timeout.ValueString()
+	}
+
+	timeout, err := time.ParseDuration(timeoutString)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid timeout", err.Error())
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := &cloudmeta.Client{HTTP: d.client, Endpoints: endpoints}
+	detectCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	instance, err := client.Detect(detectCtx, providers)
+
+	if err != nil {
+		// Not being on a cloud VM is the usual case on a workstation
+		tflog.Debug(ctx, "No cloud metadata service found", map[string]any{"error": err.Error()})
+		instance = &cloudmeta.Instance{}
+	}
+
+	data.Id = types.StringValue("none")
+
+	if instance.InstanceID != "" {
+		data.Id = types.StringValue(instance.Provider + "/" + instance.InstanceID)
+	}
+
+	data.IsCloud = types.BoolValue(instance.Provider != "")
+	data.Provider = optionalString(instance.Provider)
+	data.InstanceId = optionalString(instance.InstanceID)
+	data.Name = optionalString(instance.Name)
+	data.Region = optionalString(instance.Region)
+	data.Zone = optionalString(instance.Zone)
+	data.AccountId = optionalString(instance.Account)
+	data.InstanceType = optionalString(instance.InstanceType)
+	data.PrivateIp = optionalString(instance.PrivateIP)
+	data.PublicIp = optionalString(instance.PublicIP)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Read cloud_instance data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
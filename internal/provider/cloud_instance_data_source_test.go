@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCloudInstanceDataSource(t *testing.T) {
+	// Fake DigitalOcean metadata service
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metadata/v1.json" {
+			http.NotFound(w, r)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{
+  "droplet_id": 2756294, "hostname": "bastion", "region": "nyc3",
+  "interfaces": {"public": [{"ipv4": {"ip_address": "104.131.20.105"}}], "private": [{"ipv4": {"ip_address": "10.132.255.113"}}]}
+}`))
+	}))
+	defer server.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: fmt.Sprintf(`
+data "localos_cloud_instance" "test" {
+  providers = ["digitalocean"]
+  endpoints = {
+    digitalocean = %q
+  }
+}
+`, server.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.localos_cloud_instance.test", "id", "digitalocean/2756294"),
+					resource.TestCheckResourceAttr("data.localos_cloud_instance.test", "is_cloud", "true"),
+					resource.TestCheckResourceAttr("data.localos_cloud_instance.test", "provider_name", "digitalocean"),
+					resource.TestCheckResourceAttr("data.localos_cloud_instance.test", "instance_id", "2756294"),
+					resource.TestCheckResourceAttr("data.localos_cloud_instance.test", "name", "bastion"),
+					resource.TestCheckResourceAttr("data.localos_cloud_instance.test", "region", "nyc3"),
+					resource.TestCheckNoResourceAttr("data.localos_cloud_instance.test", "zone"),
+					resource.TestCheckResourceAttr("data.localos_cloud_instance.test", "private_ip", "10.132.255.113"),
+					resource.TestCheckResourceAttr("data.localos_cloud_instance.test", "public_ip", "104.131.20.105"),
+				),
+			},
+			// No metadata service
+			{
+				Config: fmt.Sprintf(`
+data "localos_cloud_instance" "test" {
+  providers = ["aws"]
+  endpoints = {
+    aws = "%s/missing"
+  }
+}
+`, server.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.localos_cloud_instance.test", "id", "none"),
+					resource.TestCheckResourceAttr("data.localos_cloud_instance.test", "is_cloud", "false"),
+					resource.TestCheckNoResourceAttr("data.localos_cloud_instance.test", "instance_id"),
+				),
+			},
+		},
+	})
+}
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"net"
 	"net/http"
 
 	"github.com/fireflycons/terraform-provider-localos/internal/helpers/privateip"
@@ -8,5 +9,6 @@ import (
 
 type ConfigurationData struct {
 	httpClient      *http.Client
+	dialer          *net.Dialer
 	localInterfaces privateip.LocalInterfaces
 }
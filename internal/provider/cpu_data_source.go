@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/cpuinfo"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CpuDataSource{}
+
+func NewCpuDataSource() datasource.DataSource {
+	return &CpuDataSource{}
+}
+
+// CpuDataSource defines the data source implementation.
+type CpuDataSource struct {
+}
+
+// CpuDataSourceModel describes the data source data model.
+type CpuDataSourceModel struct {
+	Id               types.String  `tfsdk:"id"`
+	LogicalCpus      types.Int64   `tfsdk:"logical_cpus"`
+	PhysicalCores    types.Int64   `tfsdk:"physical_cores"`
+	Sockets          types.Int64   `tfsdk:"sockets"`
+	ThreadsPerCore   types.Int64   `tfsdk:"threads_per_core"`
+	Vendor           types.String  `tfsdk:"vendor"`
+	ModelName        types.String  `tfsdk:"model_name"`
+	BaseFrequencyMHz types.Float64 `tfsdk:"base_frequency_mhz"`
+	MaxFrequencyMHz  types.Float64 `tfsdk:"max_frequency_mhz"`
+	Flags            types.List    `tfsdk:"flags"`
+	AvailableCpus    types.Int64   `tfsdk:"available_cpus"`
+	CpuSet           types.List    `tfsdk:"cpu_set"`
+}
+
+func (d *CpuDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cpu"
+}
+
+func (d *CpuDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "The `cpu` data source gets the CPU topology and features of the machine that is running terraform, " +
+			"and the CPUs that terraform is allowed to run on. Details other than CPU counts are only available on Linux.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier",
+				Computed:            true,
+			},
+			"logical_cpus": schema.Int64Attribute{
+				MarkdownDescription: "Number of online logical CPUs (hardware threads). " +
+					"On systems other than Linux, the number of CPUs available to terraform.",
+				Computed: true,
+			},
+			"physical_cores": schema.Int64Attribute{
+				MarkdownDescription: "Number of physical cores over all sockets. Null if the topology is not known, e.g. on some ARM systems.",
+				Computed:            true,
+			},
+			"sockets": schema.Int64Attribute{
+				MarkdownDescription: "Number of CPU packages. Null if the topology is not known.",
+				Computed:            true,
+			},
+			"threads_per_core": schema.Int64Attribute{
+				MarkdownDescription: "Logical CPUs per physical core, e.g. 2 with hyperthreading. Null if the topology is not known.",
+				Computed:            true,
+			},
+			"vendor": schema.StringAttribute{
+				MarkdownDescription: "CPU vendor, e.g. `GenuineIntel`, `AuthenticAMD`, or for ARM CPUs the implementer, e.g. `ARM`, `Ampere`.",
+				Computed:            true,
+			},
+			"model_name": schema.StringAttribute{
+				MarkdownDescription: "CPU model, e.g. `Intel(R) Core(TM) i7-8550U CPU @ 1.80GHz`. For ARM CPUs this may be the model of the board.",
+				Computed:            true,
+			},
+			"base_frequency_mhz": schema.Float64Attribute{
+				MarkdownDescription: "Base (non-turbo) frequency in MHz, from cpufreq or the model name. Null if unknown.",
+				Computed:            true,
+			},
+			"max_frequency_mhz": schema.Float64Attribute{
+				MarkdownDescription: "Maximum frequency in MHz, from cpufreq. Null if unknown, as in most virtual machines.",
+				Computed:            true,
+			},
+			"flags": schema.ListAttribute{
+				MarkdownDescription: "Features of the CPU, sorted, e.g. `avx2`, `aes` on x86 or `sve`, `asimd` on ARM. Use `contains()` to test for a feature.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"available_cpus": schema.Int64Attribute{
+				MarkdownDescription: "Number of CPUs terraform is allowed to run on, which may be less than `logical_cpus` in a container or under `taskset`.",
+				Computed:            true,
+			},
+			"cpu_set": schema.ListAttribute{
+				MarkdownDescription: "Numbers of the CPUs terraform is allowed to run on, from `sched_getaffinity`. Null on systems other than Linux.",
+				ElementType:         types.Int64Type,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CpuDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Nothing to configure
+}
+
+func (d *CpuDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CpuDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info := &cpuinfo.Info{
+		Logical: runtime.NumCPU(),
+	}
+
+	if runtime.GOOS != "linux" {
+		resp.Diagnostics.AddWarning("CPU details are not available", fmt.Sprintf("Reading CPU details is not supported on %s", runtime.GOOS))
+	} else if i, err := cpuinfo.Read(cpuinfo.DefaultProcRoot, cpuinfo.DefaultSysRoot); err != nil {
+		resp.Diagnostics.AddWarning("Unable to read CPU details", err.Error())
+	} else {
+		info = i
+	}
+
+	data.Id = types.StringValue("cpu")
+	data.LogicalCpus = types.Int64Value(int64(info.Logical))
+	data.PhysicalCores = optionalInt64(int64(info.Physical))
+	data.Sockets = optionalInt64(int64(info.Sockets))
+	data.ThreadsPerCore = types.Int64Null()
+
+	if info.Physical > 0 {
+		data.ThreadsPerCore = types.Int64Value(int64(info.Logical / info.Physical))
+	}
+
+	data.Vendor = optionalString(info.Vendor)
+	data.ModelName = optionalString(info.ModelName)
+	data.BaseFrequencyMHz = optionalFloat64(info.BaseFrequencyMHz)
+	data.MaxFrequencyMHz = optionalFloat64(info.MaxFrequencyMHz)
+
+	var diags diag.Diagnostics
+	data.Flags, diags = types.ListValueFrom(ctx, types.StringType, info.Flags)
+	resp.Diagnostics.Append(diags...)
+
+	// runtime.NumCPU is the size of the affinity set, where there is one
+	data.AvailableCpus = types.Int64Value(int64(runtime.NumCPU()))
+	data.CpuSet = types.ListNull(types.Int64Type)
+
+	if cpus, err := cpuinfo.Affinity(); err == nil {
+		data.AvailableCpus = types.Int64Value(int64(len(cpus)))
+		data.CpuSet, diags = types.ListValueFrom(ctx, types.Int64Type, cpus)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Read cpu data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// optionalFloat64 returns null for 0, which is used for unknown measurements.
+func optionalFloat64(f float64) types.Float64 {
+	if f == 0 {
+		return types.Float64Null()
+	}
+
+	return types.Float64Value(f)
+}
@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCpuDataSource(t *testing.T) {
+	checks := []resource.TestCheckFunc{
+		resource.TestCheckResourceAttr("data.localos_cpu.test", "id", "cpu"),
+		resource.TestCheckResourceAttrSet("data.localos_cpu.test", "logical_cpus"),
+		resource.TestCheckResourceAttr("data.localos_cpu.test", "available_cpus", strconv.Itoa(runtime.NumCPU())),
+	}
+
+	if runtime.GOOS == "linux" {
+		checks = append(checks,
+			resource.TestCheckResourceAttr("data.localos_cpu.test", "cpu_set.#", strconv.Itoa(runtime.NumCPU())),
+			resource.TestCheckResourceAttrSet("data.localos_cpu.test", "flags.#"),
+		)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: `data "localos_cpu" "test" {}`,
+				Check:  resource.ComposeAggregateTestCheckFunc(checks...),
+			},
+		},
+	})
+}
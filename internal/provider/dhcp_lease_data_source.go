@@ -0,0 +1,245 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/dhcplease"
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/privateip"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DhcpLeaseDataSource{}
+
+func NewDhcpLeaseDataSource() datasource.DataSource {
+	return &DhcpLeaseDataSource{}
+}
+
+// DhcpLeaseDataSource defines the data source implementation.
+type DhcpLeaseDataSource struct {
+	localInterfaces privateip.LocalInterfaces
+}
+
+// DhcpLeaseDataSourceModel describes the data source data model.
+type DhcpLeaseDataSourceModel struct {
+	Id         types.String `tfsdk:"id"`
+	Interface  types.String `tfsdk:"interface"`
+	Found      types.Bool   `tfsdk:"found"`
+	Source     types.String `tfsdk:"source"`
+	Path       types.String `tfsdk:"path"`
+	Address    types.String `tfsdk:"address"`
+	SubnetMask types.String `tfsdk:"subnet_mask"`
+	Server     types.String `tfsdk:"server"`
+	Routers    types.List   `tfsdk:"routers"`
+	Domain     types.String `tfsdk:"domain"`
+	DnsServers types.List   `tfsdk:"dns_servers"`
+	NtpServers types.List   `tfsdk:"ntp_servers"`
+	Start      types.String `tfsdk:"start"`
+	Renew      types.String `tfsdk:"renew"`
+	Expiry     types.String `tfsdk:"expiry"`
+}
+
+func (d *DhcpLeaseDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dhcp_lease"
+}
+
+func (d *DhcpLeaseDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "`dhcp_lease` data source gets the DHCP lease held by a network interface of the machine that reads the data source, " +
+			"from the lease files of dhclient, systemd-networkd or NetworkManager. This data source is only supported on Linux.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier",
+				Computed:            true,
+			},
+			"interface": schema.StringAttribute{
+				MarkdownDescription: "Network interface, e.g. `eth0`. Default is the primary interface as reported by `localos_private_ip`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"found": schema.BoolAttribute{
+				MarkdownDescription: "Whether a lease was found. If `false`, e.g. because the interface is statically configured, all other attributes are null.",
+				Computed:            true,
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "DHCP client that holds the lease: `dhclient`, `systemd-networkd` or `networkmanager`",
+				Computed:            true,
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Lease file the lease was read from",
+				Computed:            true,
+			},
+			"address": schema.StringAttribute{
+				MarkdownDescription: "Leased IP address",
+				Computed:            true,
+			},
+			"subnet_mask": schema.StringAttribute{
+				MarkdownDescription: "Subnet mask",
+				Computed:            true,
+			},
+			"server": schema.StringAttribute{
+				MarkdownDescription: "Address of the DHCP server that granted the lease",
+				Computed:            true,
+			},
+			"routers": schema.ListAttribute{
+				MarkdownDescription: "Default routers offered by the server",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "Domain name offered by the server",
+				Computed:            true,
+			},
+			"dns_servers": schema.ListAttribute{
+				MarkdownDescription: "DNS servers offered by the server",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"ntp_servers": schema.ListAttribute{
+				MarkdownDescription: "NTP servers offered by the server",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"start": schema.StringAttribute{
+				MarkdownDescription: "When the lease was obtained or last renewed, in RFC 3339 format. " +
+					"For systemd-networkd and NetworkManager this is when the lease file was written.",
+				Computed: true,
+			},
+			"renew": schema.StringAttribute{
+				MarkdownDescription: "When the client will try to renew the lease, in RFC 3339 format",
+				Computed:            true,
+			},
+			"expiry": schema.StringAttribute{
+				MarkdownDescription: "When the lease expires, in RFC 3339 format. The address may change after this time.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DhcpLeaseDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	configData, ok := req.ProviderData.(ConfigurationData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected ConfigurationData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.localInterfaces = configData.localInterfaces
+}
+
+func (d *DhcpLeaseDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DhcpLeaseDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Interface.IsNull() {
+		if err := d.localInterfaces.ScanInterfaces(); err != nil {
+			resp.Diagnostics.AddError(err.Error(), "This is an error with the provider.")
+			return
+		}
+
+		p := d.localInterfaces.GetPrimary()
+
+		if p == nil {
+			resp.Diagnostics.AddError("No primary network interface detected",
+				d.localInterfaces.GetPrimaryAbsentReason()+". Set interface to choose one.")
+			return
+		}
+
+		data.Interface = types.StringValue(p.Name)
+	}
+
+	var lease *dhcplease.Lease
+
+	if runtime.GOOS != "linux" {
+		resp.Diagnostics.AddWarning("DHCP leases are not available", fmt.Sprintf("Reading DHCP leases is not supported on %s", runtime.GOOS))
+	} else {
+		var err error
+
+		// Files that cannot be read are skipped, so the lease may still be found in others
+		if lease, err = dhcplease.Find(dhcplease.DefaultPaths, data.Interface.ValueString()); err != nil {
+			resp.Diagnostics.AddWarning("Unable to read some DHCP lease files", err.Error())
+		}
+	}
+
+	if lease == nil {
+		// Nil slices become null lists
+		lease = &dhcplease.Lease{}
+	}
+
+	data.Found = types.BoolValue(lease.Source != "")
+	data.Source = optionalString(lease.Source)
+	data.Path = optionalString(lease.Path)
+	data.Address = optionalString(lease.Address)
+	data.SubnetMask = optionalString(lease.SubnetMask)
+	data.Server = optionalString(lease.Server)
+	data.Domain = optionalString(lease.Domain)
+	data.Start = optionalTime(lease.Start)
+	data.Renew = optionalTime(lease.Renew)
+	data.Expiry = optionalTime(lease.Expiry)
+
+	var diags diag.Diagnostics
+	data.Routers, diags = types.ListValueFrom(ctx, types.StringType, lease.Routers)
+	resp.Diagnostics.Append(diags...)
+	data.DnsServers, diags = types.ListValueFrom(ctx, types.StringType, lease.DNS)
+	resp.Diagnostics.Append(diags...)
+	data.NtpServers, diags = types.ListValueFrom(ctx, types.StringType, lease.NTP)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = data.Interface
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Read dhcp_lease data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// optionalString returns null for an empty string.
+func optionalString(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(s)
+}
+
+// optionalTime returns null for the zero time, else the time in RFC 3339 format.
+func optionalTime(t time.Time) types.String {
+	if t.IsZero() {
+		return types.StringNull()
+	}
+
+	return types.StringValue(t.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/privateip"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDhcpLeaseDataSourceNoLease(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: `data "localos_dhcp_lease" "test" { interface = "no-such-if0" }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.localos_dhcp_lease.test", "id", "no-such-if0"),
+					resource.TestCheckResourceAttr("data.localos_dhcp_lease.test", "found", "false"),
+					resource.TestCheckNoResourceAttr("data.localos_dhcp_lease.test", "address"),
+					resource.TestCheckNoResourceAttr("data.localos_dhcp_lease.test", "dns_servers"),
+				),
+			},
+		},
+	})
+}
+
+// Test that the primary interface is used when none is given.
+func TestAccDhcpLeaseDataSourceDefaultInterface(t *testing.T) {
+	// Expectations are optional, as they are not met when acceptance tests are skipped
+	mock := privateip.NewMockLocalInterfaces(t)
+	mock.On("ScanInterfaces").Return(nil).Maybe()
+	mock.On("GetPrimary").Return(&privateip.NIC{
+		Ip:        "172.31.0.1",
+		Network:   "172.31.0.0/16",
+		Name:      "test-primary",
+		IsPrimary: true,
+	}).Maybe()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"localos": providerserver.NewProtocol6WithError(newProviderWithMock("test", mock)()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `data "localos_dhcp_lease" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.localos_dhcp_lease.test", "interface", "test-primary"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDhcpLeaseDataSourceNoPrimaryRaisesError(t *testing.T) {
+	mock := privateip.NewMockLocalInterfaces(t)
+	mock.On("ScanInterfaces").Return(nil).Maybe()
+	mock.On("GetPrimary").Return(nil).Maybe()
+	mock.On("GetPrimaryAbsentReason").Return("no default gateway").Maybe()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"localos": providerserver.NewProtocol6WithError(newProviderWithMock("test", mock)()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      `data "localos_dhcp_lease" "test" {}`,
+				ExpectError: regexp.MustCompile(`No primary network interface detected`),
+			},
+		},
+	})
+}
@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/dnsconfig"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DnsConfigDataSource{}
+
+func NewDnsConfigDataSource() datasource.DataSource {
+	return &DnsConfigDataSource{}
+}
+
+// DnsConfigDataSource defines the data source implementation.
+type DnsConfigDataSource struct {
+}
+
+// DnsConfigDataSourceModel describes the data source data model.
+type DnsConfigDataSourceModel struct {
+	Id                  types.String `tfsdk:"id"`
+	Nameservers         types.List   `tfsdk:"nameservers"`
+	Search              types.List   `tfsdk:"search"`
+	Domain              types.String `tfsdk:"domain"`
+	Ndots               types.Int64  `tfsdk:"ndots"`
+	Timeout             types.Int64  `tfsdk:"timeout"`
+	Attempts            types.Int64  `tfsdk:"attempts"`
+	Rotate              types.Bool   `tfsdk:"rotate"`
+	Options             types.List   `tfsdk:"options"`
+	SystemdResolvedStub types.Bool   `tfsdk:"systemd_resolved_stub"`
+	UpstreamNameservers types.List   `tfsdk:"upstream_nameservers"`
+	NsswitchHosts       types.List   `tfsdk:"nsswitch_hosts"`
+}
+
+func (d *DnsConfigDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_config"
+}
+
+func (d *DnsConfigDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "The `dns_config` data source gets the system resolver configuration of the machine that is running terraform " +
+			"from `/etc/resolv.conf` and `/etc/nsswitch.conf`. It is not supported on Windows.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier",
+				Computed:            true,
+			},
+			"nameservers": schema.ListAttribute{
+				MarkdownDescription: "Name servers in the order they are listed in `resolv.conf`",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"search": schema.ListAttribute{
+				MarkdownDescription: "Search domains. If `resolv.conf` has a `domain` directive rather than `search`, this contains that domain.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "Local domain name from the `domain` directive. Empty if `search` is used instead.",
+				Computed:            true,
+			},
+			"ndots": schema.Int64Attribute{
+				MarkdownDescription: "Value of `options ndots`, or the resolver default of 1",
+				Computed:            true,
+			},
+			"timeout": schema.Int64Attribute{
+				MarkdownDescription: "Value of `options timeout` in seconds, or the resolver default of 5",
+				Computed:            true,
+			},
+			"attempts": schema.Int64Attribute{
+				MarkdownDescription: "Value of `options attempts`, or the resolver default of 2",
+				Computed:            true,
+			},
+			"rotate": schema.BoolAttribute{
+				MarkdownDescription: "Whether `options rotate` is set",
+				Computed:            true,
+			},
+			"options": schema.ListAttribute{
+				MarkdownDescription: "All options from `options` directives, as written",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"systemd_resolved_stub": schema.BoolAttribute{
+				MarkdownDescription: "Whether name resolution goes via the systemd-resolved stub resolver",
+				Computed:            true,
+			},
+			"upstream_nameservers": schema.ListAttribute{
+				MarkdownDescription: "When the systemd-resolved stub is in use, the name servers it forwards to, " +
+					"read from `/run/systemd/resolve/resolv.conf`. Null otherwise.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"nsswitch_hosts": schema.ListAttribute{
+				MarkdownDescription: "Sources on the `hosts:` line of `/etc/nsswitch.conf`, e.g. `[\"files\", \"dns\"]`. Null if there is no `nsswitch.conf`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DnsConfigDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Nothing to configure
+}
+
+func (d *DnsConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DnsConfigDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(dnsconfig.DefaultPaths.ResolvConf)
+
+	cfg := &dnsconfig.Config{}
+
+	if runtime.GOOS == "windows" {
+		resp.Diagnostics.AddWarning("DNS configuration is not available", fmt.Sprintf("Reading DNS configuration is not supported on %s", runtime.GOOS))
+	} else {
+		var err error
+
+		if cfg, err = dnsconfig.Read(dnsconfig.DefaultPaths); err != nil {
+			resp.Diagnostics.AddError("Unable to read DNS configuration", err.Error())
+			return
+		}
+
+		data.Domain = types.StringValue(cfg.Domain)
+		data.Ndots = types.Int64Value(int64(cfg.Ndots))
+		data.Timeout = types.Int64Value(int64(cfg.Timeout))
+		data.Attempts = types.Int64Value(int64(cfg.Attempts))
+		data.Rotate = types.BoolValue(cfg.Rotate)
+		data.SystemdResolvedStub = types.BoolValue(cfg.SystemdResolvedStub)
+	}
+
+	// Nil slices become null lists
+	var diags diag.Diagnostics
+	data.Nameservers, diags = types.ListValueFrom(ctx, types.StringType, cfg.Nameservers)
+	resp.Diagnostics.Append(diags...)
+	data.Search, diags = types.ListValueFrom(ctx, types.StringType, cfg.Search)
+	resp.Diagnostics.Append(diags...)
+	data.Options, diags = types.ListValueFrom(ctx, types.StringType, cfg.Options)
+	resp.Diagnostics.Append(diags...)
+	data.UpstreamNameservers, diags = types.ListValueFrom(ctx, types.StringType, cfg.UpstreamNameservers)
+	resp.Diagnostics.Append(diags...)
+	data.NsswitchHosts, diags = types.ListValueFrom(ctx, types.StringType, cfg.NsswitchHosts)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Read dns_config data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
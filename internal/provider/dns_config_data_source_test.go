@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"os"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/dnsconfig"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDnsConfigDataSource(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skipf("DNS configuration is not supported on %s", runtime.GOOS)
+	}
+
+	f, err := os.Open(dnsconfig.DefaultPaths.ResolvConf)
+
+	if err != nil {
+		t.Skipf("Cannot read %s: %s", dnsconfig.DefaultPaths.ResolvConf, err)
+	}
+
+	expected, err := dnsconfig.ParseResolvConf(f)
+	f.Close()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checks := []resource.TestCheckFunc{
+		resource.TestCheckResourceAttr("data.localos_dns_config.test", "id", dnsconfig.DefaultPaths.ResolvConf),
+		resource.TestCheckResourceAttr("data.localos_dns_config.test", "nameservers.#", strconv.Itoa(len(expected.Nameservers))),
+		resource.TestCheckResourceAttr("data.localos_dns_config.test", "search.#", strconv.Itoa(len(expected.Search))),
+		resource.TestCheckResourceAttr("data.localos_dns_config.test", "ndots", strconv.Itoa(expected.Ndots)),
+		resource.TestCheckResourceAttr("data.localos_dns_config.test", "timeout", strconv.Itoa(expected.Timeout)),
+		resource.TestCheckResourceAttr("data.localos_dns_config.test", "attempts", strconv.Itoa(expected.Attempts)),
+		resource.TestCheckResourceAttr("data.localos_dns_config.test", "rotate", strconv.FormatBool(expected.Rotate)),
+	}
+
+	for i, ns := range expected.Nameservers {
+		checks = append(checks, resource.TestCheckResourceAttr("data.localos_dns_config.test", "nameservers."+strconv.Itoa(i), ns))
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: `data "localos_dns_config" "test" {}`,
+				Check:  resource.ComposeAggregateTestCheckFunc(checks...),
+			},
+		},
+	})
+}
@@ -0,0 +1,202 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/dnslookup"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultDnsLookupType    = "A"
+	defaultDnsLookupTimeout = "5s"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DnsLookupDataSource{}
+
+func NewDnsLookupDataSource() datasource.DataSource {
+	return &DnsLookupDataSource{}
+}
+
+// DnsLookupDataSource defines the data source implementation.
+type DnsLookupDataSource struct {
+}
+
+// DnsLookupDataSourceModel describes the data source data model.
+type DnsLookupDataSourceModel struct {
+	Id      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Type    types.String `tfsdk:"type"`
+	Server  types.String `tfsdk:"server"`
+	Timeout types.String `tfsdk:"timeout"`
+	Records types.List   `tfsdk:"records"` //< DnsRecordModel
+	Values  types.List   `tfsdk:"values"`
+}
+
+type DnsRecordModel struct {
+	Name  types.String `tfsdk:"name"`
+	Type  types.String `tfsdk:"type"`
+	TTL   types.Int64  `tfsdk:"ttl"`
+	Value types.String `tfsdk:"value"`
+}
+
+func (d *DnsLookupDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_lookup"
+}
+
+func (d *DnsLookupDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "`dns_lookup` data source looks up DNS records using the resolver of the machine that reads the data source, " +
+			"so that names only visible inside e.g. a corporate network resolve as they do on the workstation.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name to look up. For `PTR` lookups this may be an IP address.",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Record type, one of %s. Default `%s`.", "`"+strings.Join(dnslookup.SupportedTypes(), "`, `")+"`", defaultDnsLookupType),
+				Optional:            true,
+			},
+			"server": schema.StringAttribute{
+				MarkdownDescription: "Name server to query as `host` or `host:port`. By default the name is resolved as other programs on the machine resolve it, " +
+					"using the hosts file, search domains and split DNS configuration such as `/etc/resolver` on macOS.",
+				Optional: true,
+			},
+			"timeout": schema.StringAttribute{
+				MarkdownDescription: "Timeout for the lookup as a Go duration string. Default `" + defaultDnsLookupTimeout + "`.",
+				Optional:            true,
+			},
+			"records": schema.ListAttribute{
+				MarkdownDescription: "Records found, sorted by value",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: dnsRecordAttributeTypes(),
+				},
+			},
+			"values": schema.ListAttribute{
+				MarkdownDescription: "Values of `records`, sorted",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *DnsLookupDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Nothing to configure
+}
+
+func dnsRecordAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"name":  types.StringType,
+		"type":  types.StringType,
+		"ttl":   types.Int64Type,
+		"value": types.StringType,
+	}
+}
+
+func (d *DnsLookupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DnsLookupDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordType := defaultDnsLookupType
+
+	if !data.Type.IsNull() {
+		recordType = strings.ToUpper(data.Type.ValueString())
+	}
+
+	timeoutString := defaultDnsLookupTimeout
+
+	if !data.Timeout.IsNull() {
+		timeoutString = data.Timeout.ValueString()
+	}
+
+	timeout, err := time.ParseDuration(timeoutString)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid timeout", err.Error())
+		return
+	}
+
+	var records []*dnslookup.Record
+
+	if !data.Server.IsNull() {
+		records, err = dnslookup.Lookup(ctx, []string{data.Server.ValueString()}, data.Name.ValueString(), recordType, timeout)
+	} else {
+		records, err = dnslookup.LookupSystem(ctx, data.Name.ValueString(), recordType, timeout)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("DNS lookup failed", err.Error())
+		return
+	}
+
+	recordModels := make([]DnsRecordModel, 0, len(records))
+	values := make([]string, 0, len(records))
+
+	for _, r := range records {
+		recordModels = append(recordModels, dnsRecordToModel(r))
+		values = append(values, r.Value)
+	}
+
+	resp.Diagnostics.Append(tfsdk.ValueFrom(ctx, recordModels, types.ListType{
+		ElemType: types.ObjectType{
+			AttrTypes: dnsRecordAttributeTypes(),
+		},
+	}, &data.Records)...)
+
+	resp.Diagnostics.Append(tfsdk.ValueFrom(ctx, values, types.ListType{
+		ElemType: types.StringType,
+	}, &data.Values)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(recordType + "/" + data.Name.ValueString())
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Read dns_lookup data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func dnsRecordToModel(r *dnslookup.Record) DnsRecordModel {
+	m := DnsRecordModel{
+		Name:  types.StringValue(r.Name),
+		Type:  types.StringValue(r.Type),
+		TTL:   types.Int64Null(),
+		Value: types.StringValue(r.Value),
+	}
+
+	if r.TTL != nil {
+		m.TTL = types.Int64Value(int64(*r.TTL))
+	}
+
+	return m
+}
@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func TestAccDnsLookupDataSource(t *testing.T) {
+	server := newStubNameServer(t, map[string][][4]byte{
+		"app.corp.example.": {{10, 1, 0, 20}, {10, 1, 0, 10}},
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: fmt.Sprintf(`
+data "localos_dns_lookup" "test" {
+  name   = "app.corp.example"
+  server = "%s"
+}`, server),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.localos_dns_lookup.test", "id", "A/app.corp.example"),
+					resource.TestCheckResourceAttr("data.localos_dns_lookup.test", "values.#", "2"),
+					resource.TestCheckResourceAttr("data.localos_dns_lookup.test", "values.0", "10.1.0.10"),
+					resource.TestCheckResourceAttr("data.localos_dns_lookup.test", "values.1", "10.1.0.20"),
+					resource.TestCheckResourceAttr("data.localos_dns_lookup.test", "records.0.name", "app.corp.example."),
+					resource.TestCheckResourceAttr("data.localos_dns_lookup.test", "records.0.type", "A"),
+					resource.TestCheckResourceAttr("data.localos_dns_lookup.test", "records.0.ttl", "60"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDnsLookupDataSourceNXDomain(t *testing.T) {
+	server := newStubNameServer(t, map[string][][4]byte{})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "localos_dns_lookup" "test" {
+  name   = "missing.corp.example"
+  server = "%s"
+}`, server),
+				ExpectError: regexp.MustCompile(`NXDOMAIN`),
+			},
+		},
+	})
+}
+
+// newStubNameServer starts a UDP name server on localhost that answers A queries
+// from the given records and returns its address.
+func newStubNameServer(t *testing.T, records map[string][][4]byte) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+
+			if err != nil {
+				return
+			}
+
+			var msg dnsmessage.Message
+
+			if err = msg.Unpack(buf[:n]); err != nil || len(msg.Questions) != 1 {
+				continue
+			}
+
+			q := msg.Questions[0]
+			msg.Response = true
+			addrs, ok := records[q.Name.String()]
+
+			if !ok {
+				msg.RCode = dnsmessage.RCodeNameError
+			}
+
+			for _, a := range addrs {
+				msg.Answers = append(msg.Answers, dnsmessage.Resource{
+					Header: dnsmessage.ResourceHeader{Name: q.Name, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET, TTL: 60},
+					Body:   &dnsmessage.AResource{A: a},
+				})
+			}
+
+			if resp, err := msg.Pack(); err == nil {
+				_, _ = conn.WriteTo(resp, addr)
+			}
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
@@ -0,0 +1,186 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/dotenv"
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/envvars"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DotenvDataSource{}
+
+func NewDotenvDataSource() datasource.DataSource {
+	return &DotenvDataSource{}
+}
+
+// DotenvDataSource defines the data source implementation.
+type DotenvDataSource struct {
+}
+
+// DotenvDataSourceModel describes the data source data model.
+type DotenvDataSourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	Files           types.List   `tfsdk:"files"`
+	IgnoreMissing   types.Bool   `tfsdk:"ignore_missing"`
+	Sensitive       types.List   `tfsdk:"sensitive"`
+	Values          types.Map    `tfsdk:"values"`
+	SensitiveValues types.Map    `tfsdk:"sensitive_values"`
+	FilesRead       types.List   `tfsdk:"files_read"`
+}
+
+func (d *DotenvDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dotenv"
+}
+
+func (d *DotenvDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "The `dotenv` data source reads variables from one or more `.env` files, " +
+			"so that terraform can use the same local settings as the application.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier",
+				Computed:            true,
+			},
+			"files": schema.ListAttribute{
+				MarkdownDescription: "Paths of the files to read, in order. Values in later files override those in earlier files. " +
+					"Relative paths are relative to the directory terraform is run in, so use `path.module` to refer to files alongside the configuration.",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"ignore_missing": schema.BoolAttribute{
+				MarkdownDescription: "If true, files that do not exist are skipped, e.g. an optional `.env.local`. Otherwise a missing file is an error.",
+				Optional:            true,
+			},
+			"sensitive": schema.ListAttribute{
+				MarkdownDescription: "Keys that hold secrets, and are returned in `sensitive_values` rather than `values`. " +
+					"A pattern is a prefix of the key, e.g. `SECRET_`, or a regular expression between slashes, e.g. `/(PASSWORD|TOKEN)$/`. " +
+					"Use `[\"/./\"]` to make all keys sensitive.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"values": schema.MapAttribute{
+				MarkdownDescription: "Map of the variables whose keys do not match `sensitive`, with key=variable name, value=variable value.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"sensitive_values": schema.MapAttribute{
+				MarkdownDescription: "Map of the variables whose keys match `sensitive`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"files_read": schema.ListAttribute{
+				MarkdownDescription: "Paths of the files that were read, which differs from `files` when `ignore_missing` is true.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DotenvDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Nothing to configure
+}
+
+func (d *DotenvDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DotenvDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var files, sensitive []string
+	resp.Diagnostics.Append(data.Files.ElementsAs(ctx, &files, false)...)
+	resp.Diagnostics.Append(data.Sensitive.ElementsAs(ctx, &sensitive, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	patterns, err := envvars.ParsePatterns(sensitive)
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("sensitive"), "Invalid pattern", err.Error())
+		return
+	}
+
+	parser := dotenv.New(os.LookupEnv)
+	filesRead := make([]string, 0, len(files))
+
+	for _, f := range files {
+		err := parser.ParseFile(f)
+
+		if errors.Is(err, fs.ErrNotExist) && data.IgnoreMissing.ValueBool() {
+			continue
+		}
+
+		var syntaxErr *dotenv.SyntaxError
+
+		if errors.As(err, &syntaxErr) {
+			resp.Diagnostics.AddError("Invalid dotenv file", err.Error())
+			return
+		}
+
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to read dotenv file", err.Error())
+			return
+		}
+
+		filesRead = append(filesRead, f)
+	}
+
+	if undefined := parser.Undefined(); len(undefined) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Undefined variables in dotenv file",
+			fmt.Sprintf("These variables are referenced, but are not set in the files or the environment, so are replaced with an empty string: %s", strings.Join(undefined, ", ")),
+		)
+	}
+
+	values := parser.Values()
+	sensitiveValues := map[string]string{}
+
+	if len(patterns) > 0 {
+		sensitiveValues = envvars.Filter(values, patterns, nil)
+
+		for k := range sensitiveValues {
+			delete(values, k)
+		}
+	}
+
+	data.Id = types.StringValue(strings.Join(files, ","))
+
+	var diags diag.Diagnostics
+	data.Values, diags = types.MapValueFrom(ctx, types.StringType, values)
+	resp.Diagnostics.Append(diags...)
+	data.SensitiveValues, diags = types.MapValueFrom(ctx, types.StringType, sensitiveValues)
+	resp.Diagnostics.Append(diags...)
+	data.FilesRead, diags = types.ListValueFrom(ctx, types.StringType, filesRead)
+	resp.Diagnostics.Append(diags...)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Read dotenv data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
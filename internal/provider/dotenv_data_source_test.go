@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDotenvDataSource(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, ".env")
+	local := filepath.Join(dir, ".env.local")
+	bad := filepath.Join(dir, "bad.env")
+
+	t.Setenv("LOCALOS_TEST_USER", "app")
+
+	if err := os.WriteFile(base, []byte(`# Shared settings
+DB_HOST=db
+export DB_PORT=5432
+DB_PASSWORD="s3cr=t"
+DB_URL="postgres://${LOCALOS_TEST_USER}@${DB_HOST}:${DB_PORT}/app"
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(local, []byte("DB_HOST=localhost\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(bad, []byte("DB_HOST='localhost\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: fmt.Sprintf(`
+data "localos_dotenv" "test" {
+  files          = ["%s", "%s", "%s"]
+  ignore_missing = true
+  sensitive      = ["/PASSWORD$/"]
+}`, filepath.ToSlash(base), filepath.ToSlash(local), filepath.ToSlash(filepath.Join(dir, "missing.env"))),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.localos_dotenv.test", "values.%", "3"),
+					resource.TestCheckResourceAttr("data.localos_dotenv.test", "values.DB_HOST", "localhost"),
+					resource.TestCheckResourceAttr("data.localos_dotenv.test", "values.DB_URL", "postgres://app@db:5432/app"),
+					resource.TestCheckResourceAttr("data.localos_dotenv.test", "sensitive_values.DB_PASSWORD", "s3cr=t"),
+					resource.TestCheckResourceAttr("data.localos_dotenv.test", "files_read.#", "2"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+data "localos_dotenv" "test" {
+  files = ["%s"]
+}`, filepath.ToSlash(bad)),
+				ExpectError: regexp.MustCompile(`unterminated single-quoted value`),
+			},
+		},
+	})
+}
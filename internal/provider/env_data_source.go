@@ -0,0 +1,331 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/envvars"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &EnvDataSource{}
+
+func NewEnvDataSource() datasource.DataSource {
+	return &EnvDataSource{}
+}
+
+// EnvDataSource defines the data source implementation.
+type EnvDataSource struct {
+}
+
+// EnvDataSourceModel describes the data source data model.
+type EnvDataSourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Type           types.String `tfsdk:"type"`
+	Separator      types.String `tfsdk:"separator"`
+	Default        types.String `tfsdk:"default"`
+	Required       types.Bool   `tfsdk:"required"`
+	Sensitive      types.Bool   `tfsdk:"sensitive"`
+	Exists         types.Bool   `tfsdk:"exists"`
+	Value          types.String `tfsdk:"value"`
+	SensitiveValue types.String `tfsdk:"sensitive_value"`
+	Bool           types.Bool   `tfsdk:"bool"`
+	Number         types.Number `tfsdk:"number"`
+	List           types.List   `tfsdk:"list"`
+	JSON           types.String `tfsdk:"json"`
+
+	SensitiveBool   types.Bool   `tfsdk:"sensitive_bool"`
+	SensitiveNumber types.Number `tfsdk:"sensitive_number"`
+	SensitiveList   types.List   `tfsdk:"sensitive_list"`
+	SensitiveJSON   types.String `tfsdk:"sensitive_json"`
+}
+
+func (d *EnvDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_env"
+}
+
+func (d *EnvDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "The `env` data source gets a single environment variable of the process running terraform, " +
+			"checks that it is set and has the expected type, and converts it to that type.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the variable. Case sensitive, except on windows.",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the value: `string` (default), `bool`, `number`, `list` or `json`. " +
+					"The value is converted to the attribute of the same name.",
+				Optional: true,
+				Computed: true,
+			},
+			"separator": schema.StringAttribute{
+				MarkdownDescription: "Separator between the items of a `list` value. Default `,`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"default": schema.StringAttribute{
+				MarkdownDescription: "Value to use if the variable is not set. It must be of the given `type`.",
+				Optional:            true,
+			},
+			"required": schema.BoolAttribute{
+				MarkdownDescription: "If true, it is an error for the variable not to be set.",
+				Optional:            true,
+			},
+			"sensitive": schema.BoolAttribute{
+				MarkdownDescription: "If true, the value is returned in `sensitive_value`, and converted to `sensitive_bool`, `sensitive_number`, " +
+					"`sensitive_list` or `sensitive_json` instead of the attributes without the prefix, which are null. " +
+					"The value is never included in error messages.",
+				Optional: true,
+			},
+			"exists": schema.BoolAttribute{
+				MarkdownDescription: "Whether the variable is set. A variable set to an empty string exists.",
+				Computed:            true,
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "Value of the variable, or `default` if it is not set. Null if `sensitive` is true.",
+				Computed:            true,
+			},
+			"sensitive_value": schema.StringAttribute{
+				MarkdownDescription: "Value of the variable, or `default` if it is not set, when `sensitive` is true. " +
+					"A `json` value is compacted. Null if `sensitive` is false.",
+				Computed:  true,
+				Sensitive: true,
+			},
+			"bool": schema.BoolAttribute{
+				MarkdownDescription: "Value converted to a boolean, when `type` is `bool`. `true`, `yes`, `on` and `1`, and their opposites, are accepted in any case.",
+				Computed:            true,
+			},
+			"number": schema.NumberAttribute{
+				MarkdownDescription: "Value converted to a number, when `type` is `number`.",
+				Computed:            true,
+			},
+			"list": schema.ListAttribute{
+				MarkdownDescription: "Value split at each `separator`, when `type` is `list`. Whitespace around items is removed, and empty items are dropped.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"json": schema.StringAttribute{
+				MarkdownDescription: "Value checked to be JSON and compacted, when `type` is `json`. Use `jsondecode()` to get the value.",
+				Computed:            true,
+			},
+			"sensitive_bool": schema.BoolAttribute{
+				MarkdownDescription: "As `bool`, when `sensitive` is true.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"sensitive_number": schema.NumberAttribute{
+				MarkdownDescription: "As `number`, when `sensitive` is true.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"sensitive_list": schema.ListAttribute{
+				MarkdownDescription: "As `list`, when `sensitive` is true.",
+				ElementType:         types.StringType,
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"sensitive_json": schema.StringAttribute{
+				MarkdownDescription: "As `json`, when `sensitive` is true.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (d *EnvDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Nothing to configure
+}
+
+func (d *EnvDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data EnvDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	if name == "" || strings.Contains(name, "=") {
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Invalid variable name", fmt.Sprintf("%q is not a valid environment variable name", name))
+		return
+	}
+
+	if data.Type.IsNull() {
+		data.Type = types.StringValue("string")
+	}
+
+	if data.Separator.IsNull() {
+		data.Separator = types.StringValue(envvars.DefaultSeparator)
+	}
+
+	typ := data.Type.ValueString()
+
+	if !isEnvType(typ) {
+		resp.Diagnostics.AddAttributeError(path.Root("type"), "Invalid type", fmt.Sprintf("Type must be one of %s, got %q", strings.Join(envvars.Types, ", "), typ))
+		return
+	}
+
+	if data.Separator.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("separator"), "Invalid separator", "Separator must not be empty")
+		return
+	}
+
+	sensitive := data.Sensitive.ValueBool()
+	value, exists := os.LookupEnv(name)
+	data.Id = types.StringValue(name)
+	data.Exists = types.BoolValue(exists)
+	data.Value = types.StringNull()
+	data.SensitiveValue = types.StringNull()
+	data.Bool = types.BoolNull()
+	data.Number = types.NumberNull()
+	data.List = types.ListNull(types.StringType)
+	data.JSON = types.StringNull()
+	data.SensitiveBool = types.BoolNull()
+	data.SensitiveNumber = types.NumberNull()
+	data.SensitiveList = types.ListNull(types.StringType)
+	data.SensitiveJSON = types.StringNull()
+
+	// valuePath is the attribute the value comes from, or empty if from the environment
+	valuePath := path.Empty()
+
+	switch {
+	case exists:
+		// Use the value of the variable
+
+	case data.Required.ValueBool():
+		resp.Diagnostics.AddError(
+			"Missing required environment variable",
+			fmt.Sprintf("Environment variable %q is required, but is not set in the environment of terraform.", name),
+		)
+		return
+
+	case !data.Default.IsNull():
+		value = data.Default.ValueString()
+		valuePath = path.Root("default")
+
+	default:
+		// Not set, and no default, so all values are null
+		d.save(ctx, &data, resp)
+		return
+	}
+
+	// invalid reports a value that cannot be converted to the type
+	invalid := func(err error) {
+		if !valuePath.Equal(path.Empty()) {
+			resp.Diagnostics.AddAttributeError(valuePath, "Invalid default value", fmt.Sprintf("Default value of %q %s", name, err))
+			return
+		}
+
+		detail := fmt.Sprintf("Environment variable %q %s", name, err)
+
+		if !sensitive {
+			detail += fmt.Sprintf(", got %q", value)
+		}
+
+		resp.Diagnostics.AddError("Invalid environment variable value", detail)
+	}
+
+	switch typ {
+	case "bool":
+		b, err := envvars.ParseBool(value)
+
+		if err != nil {
+			invalid(err)
+			return
+		}
+
+		if sensitive {
+			data.SensitiveBool = types.BoolValue(b)
+		} else {
+			data.Bool = types.BoolValue(b)
+		}
+
+	case "number":
+		f, err := envvars.ParseNumber(value)
+
+		if err != nil {
+			invalid(err)
+			return
+		}
+
+		if sensitive {
+			data.SensitiveNumber = types.NumberValue(f)
+		} else {
+			data.Number = types.NumberValue(f)
+		}
+
+	case "list":
+		list, diags := types.ListValueFrom(ctx, types.StringType, envvars.SplitList(value, data.Separator.ValueString()))
+		resp.Diagnostics.Append(diags...)
+
+		if sensitive {
+			data.SensitiveList = list
+		} else {
+			data.List = list
+		}
+
+	case "json":
+		j, err := envvars.CompactJSON(value)
+
+		if err != nil {
+			invalid(err)
+			return
+		}
+
+		if sensitive {
+			value = j
+			data.SensitiveJSON = types.StringValue(j)
+		} else {
+			data.JSON = types.StringValue(j)
+		}
+	}
+
+	if sensitive {
+		data.SensitiveValue = types.StringValue(value)
+	} else {
+		data.Value = types.StringValue(value)
+	}
+
+	d.save(ctx, &data, resp)
+}
+
+func (d *EnvDataSource) save(ctx context.Context, data *EnvDataSourceModel, resp *datasource.ReadResponse) {
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Read env data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func isEnvType(typ string) bool {
+	for _, t := range envvars.Types {
+		if t == typ {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccEnvDataSource(t *testing.T) {
+	t.Setenv("LOCALOS_TEST_PORT", "8080")
+	t.Setenv("LOCALOS_TEST_ZONES", "a, b,,c")
+	t.Setenv("LOCALOS_TEST_TOKEN", "dGVzdA==")
+	t.Setenv("LOCALOS_TEST_DEBUG", "not a bool")
+	t.Setenv("LOCALOS_TEST_SECRET_PORTS", "5432,6379")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: `
+data "localos_env" "port" {
+  name = "LOCALOS_TEST_PORT"
+  type = "number"
+}
+
+data "localos_env" "zones" {
+  name = "LOCALOS_TEST_ZONES"
+  type = "list"
+}
+
+data "localos_env" "token" {
+  name      = "LOCALOS_TEST_TOKEN"
+  sensitive = true
+}
+
+data "localos_env" "secret_ports" {
+  name      = "LOCALOS_TEST_SECRET_PORTS"
+  type      = "list"
+  sensitive = true
+}
+
+data "localos_env" "missing" {
+  name    = "LOCALOS_TEST_MISSING"
+  type    = "bool"
+  default = "yes"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.localos_env.port", "exists", "true"),
+					resource.TestCheckResourceAttr("data.localos_env.port", "number", "8080"),
+					resource.TestCheckResourceAttr("data.localos_env.port", "value", "8080"),
+					resource.TestCheckResourceAttr("data.localos_env.zones", "list.#", "3"),
+					resource.TestCheckResourceAttr("data.localos_env.zones", "list.2", "c"),
+					resource.TestCheckNoResourceAttr("data.localos_env.token", "value"),
+					resource.TestCheckResourceAttr("data.localos_env.token", "sensitive_value", "dGVzdA=="),
+					resource.TestCheckNoResourceAttr("data.localos_env.secret_ports", "list.#"),
+					resource.TestCheckResourceAttr("data.localos_env.secret_ports", "sensitive_list.#", "2"),
+					resource.TestCheckResourceAttr("data.localos_env.secret_ports", "sensitive_list.1", "6379"),
+					resource.TestCheckResourceAttr("data.localos_env.missing", "exists", "false"),
+					resource.TestCheckResourceAttr("data.localos_env.missing", "bool", "true"),
+				),
+			},
+			{
+				Config: `
+data "localos_env" "test" {
+  name = "LOCALOS_TEST_DEBUG"
+  type = "bool"
+}`,
+				ExpectError: regexp.MustCompile(`Invalid environment variable value`),
+			},
+			{
+				Config: `
+data "localos_env" "test" {
+  name     = "LOCALOS_TEST_MISSING"
+  required = true
+}`,
+				ExpectError: regexp.MustCompile(`Missing required environment variable`),
+			},
+		},
+	})
+}
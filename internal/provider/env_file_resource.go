@@ -0,0 +1,337 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/envfile"
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/fileutil"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Permission given to environment files if not configured, as they usually hold credentials.
+const defaultEnvFilePermission = "0600"
+
+// Permission given to directories created to hold environment files.
+const envFileDirectoryMode = 0o700
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &EnvFileResource{}
+	_ resource.ResourceWithImportState    = &EnvFileResource{}
+	_ resource.ResourceWithValidateConfig = &EnvFileResource{}
+)
+
+func NewEnvFileResource() resource.Resource {
+	return &EnvFileResource{}
+}
+
+// EnvFileResource defines the resource implementation.
+type EnvFileResource struct {
+}
+
+// EnvFileResourceModel describes the resource data model.
+type EnvFileResourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	Path           types.String `tfsdk:"path"`
+	Format         types.String `tfsdk:"format"`
+	Variables      types.Map    `tfsdk:"variables"`
+	Header         types.String `tfsdk:"header"`
+	FilePermission types.String `tfsdk:"file_permission"`
+}
+
+func (r *EnvFileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_env_file"
+}
+
+func (r *EnvFileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "`env_file` resource writes a file that sets environment variables, as a `.env` file, " +
+			"a POSIX shell script or a PowerShell script, with each value quoted so that it is read back exactly. " +
+			"The file is replaced atomically, changes made outside of terraform are detected, and the file is deleted on destroy.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier, the same as `path`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Path of the file. Missing parent directories are created, accessible only by the user running terraform.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"format": schema.StringAttribute{
+				MarkdownDescription: "Format of the file: `dotenv` (default) for `KEY='value'`, `posix` for `export KEY='value'`, " +
+					"or `powershell` for `$env:KEY = 'value'`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(envfile.FormatDotenv),
+			},
+			"variables": schema.MapAttribute{
+				MarkdownDescription: "Variables to set, with key=variable name, value=variable value. " +
+					"Names may contain only letters, digits and `_`, and must not start with a digit. Variables are written in order of name.",
+				ElementType: types.StringType,
+				Required:    true,
+				Sensitive:   true,
+			},
+			"header": schema.StringAttribute{
+				MarkdownDescription: "Text written as comments at the start of the file, e.g. to say that it is managed by terraform.",
+				Optional:            true,
+			},
+			"file_permission": schema.StringAttribute{
+				MarkdownDescription: "Permission of the file, as an octal string. Default `0600`, so that only the owner can read it. " +
+					"On Windows, only the owner write bit is used, to make the file read-only.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(defaultEnvFilePermission),
+			},
+		},
+	}
+}
+
+func (r *EnvFileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Nothing to configure
+}
+
+func (r *EnvFileResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data EnvFileResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Format.IsUnknown() && !data.Format.IsNull() && !isEnvFileFormat(data.Format.ValueString()) {
+		resp.Diagnostics.AddAttributeError(path.Root("format"), "Invalid format",
+			fmt.Sprintf("Format must be one of %s, got %q", strings.Join(envfile.Formats, ", "), data.Format.ValueString()))
+	}
+
+	if !data.FilePermission.IsUnknown() && !data.FilePermission.IsNull() {
+		if _, err := parseFilePermission(data.FilePermission.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("file_permission"), "Invalid file permission", err.Error())
+		}
+	}
+
+	if data.Variables.IsUnknown() || data.Variables.IsNull() {
+		return
+	}
+
+	for name := range data.Variables.Elements() {
+		if !envfile.ValidName(name) {
+			resp.Diagnostics.AddAttributeError(path.Root("variables").AtMapKey(name), "Invalid variable name",
+				fmt.Sprintf("%q may contain only letters, digits and '_', and must not start with a digit", name))
+		}
+	}
+}
+
+func (r *EnvFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data EnvFileResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.write(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Unable to write environment file", err.Error())
+		return
+	}
+
+	data.Id = data.Path
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Created env_file resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EnvFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data EnvFileResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filePath := data.Path.ValueString()
+	content, err := os.ReadFile(filePath)
+
+	if errors.Is(err, os.ErrNotExist) {
+		// File has been deleted outside of terraform
+		tflog.Info(ctx, "Environment file not found, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read environment file", err.Error())
+		return
+	}
+
+	if data.Format.IsNull() {
+		// Imported
+		data.Format = types.StringValue(envfile.Detect(content))
+	}
+
+	vars, err := envfile.Parse(data.Format.ValueString(), content)
+
+	if err != nil {
+		// An empty map differs from the configuration, so the file will be rewritten.
+		// The error is not shown, as it may quote the variables, which are sensitive.
+		resp.Diagnostics.AddWarning("Unable to parse environment file",
+			fmt.Sprintf("%s has been changed outside of terraform, and cannot be parsed, so will be rewritten", filePath))
+		vars = map[string]string{}
+	}
+
+	// Compared as rendered, so that e.g. a trailing newline in the configuration is not a change
+	if expected, err := envfile.Render(data.Format.ValueString(), nil, data.Header.ValueString()); err == nil {
+		if header := envfile.Header(content); header != envfile.Header(expected) {
+			data.Header = optionalString(header)
+		}
+	}
+
+	v, diags := types.MapValueFrom(ctx, types.StringType, vars)
+	resp.Diagnostics.Append(diags...)
+	data.Variables = v
+
+	if info, err := os.Stat(filePath); err == nil && runtime.GOOS != "windows" {
+		perm, err := parseFilePermission(data.FilePermission.ValueString())
+
+		// Only change the state if the mode differs, so that e.g. "600" is not replaced by "0600"
+		if err != nil || perm != info.Mode().Perm() {
+			data.FilePermission = types.StringValue(fmt.Sprintf("%04o", info.Mode().Perm()))
+		}
+	}
+
+	if data.FilePermission.IsNull() {
+		data.FilePermission = types.StringValue(defaultEnvFilePermission)
+	}
+
+	tflog.Trace(ctx, "Read env_file resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EnvFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data EnvFileResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.write(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Unable to write environment file", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, "Updated env_file resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EnvFileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data EnvFileResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := os.Remove(data.Path.ValueString()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		resp.Diagnostics.AddError("Unable to delete environment file", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted env_file resource")
+}
+
+// ImportState accepts the path of the file. The format is detected from its content.
+func (r *EnvFileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("path"), req.ID)...)
+}
+
+// write renders the file described by data and writes it.
+func (r *EnvFileResource) write(ctx context.Context, data *EnvFileResourceModel) error {
+	var vars map[string]string
+
+	if diags := data.Variables.ElementsAs(ctx, &vars, false); diags.HasError() {
+		return fmt.Errorf("unable to read variables")
+	}
+
+	content, err := envfile.Render(data.Format.ValueString(), vars, data.Header.ValueString())
+
+	if err != nil {
+		return err
+	}
+
+	perm, err := parseFilePermission(data.FilePermission.ValueString())
+
+	if err != nil {
+		return err
+	}
+
+	filePath := data.Path.ValueString()
+
+	if err := os.MkdirAll(filepath.Dir(filePath), envFileDirectoryMode); err != nil {
+		return err
+	}
+
+	return fileutil.WriteAtomic(filePath, content, perm)
+}
+
+// parseFilePermission parses an octal permission such as "0600" or "644".
+func parseFilePermission(s string) (os.FileMode, error) {
+	perm, err := strconv.ParseUint(s, 8, 32)
+
+	if err != nil || len(s) < 3 || len(s) > 4 || perm > 0o777 {
+		return 0, fmt.Errorf("%q is not an octal file permission such as \"0600\"", s)
+	}
+
+	return os.FileMode(perm), nil
+}
+
+func isEnvFileFormat(format string) bool {
+	for _, f := range envfile.Formats {
+		if f == format {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccEnvFileResource(t *testing.T) {
+	path := filepath.ToSlash(filepath.Join(t.TempDir(), "dev", "stack.env"))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckEnvFileDeleted(path),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccEnvFileResourceConfig(path, "dotenv"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("localos_env_file.test", "id", path),
+					resource.TestCheckResourceAttr("localos_env_file.test", "file_permission", "0600"),
+					testAccCheckEnvFileContent(path, "# Managed by terraform\n\nDB_PASSWORD=\"it's \\$ecret\"\nDB_URL='postgres://db:5432/app'\n"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "localos_env_file.test",
+				ImportState:       true,
+				ImportStateId:     path,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccEnvFileResourceConfig(path, "posix"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckEnvFileContent(path, "# Managed by terraform\n\nexport DB_PASSWORD='it'\\''s $ecret'\nexport DB_URL='postgres://db:5432/app'\n"),
+				),
+			},
+			// Drift is detected and corrected
+			{
+				PreConfig: func() {
+					_ = os.WriteFile(path, []byte("export DB_URL='postgres://other:5432/app'\n"), 0o600)
+				},
+				Config:             testAccEnvFileResourceConfig(path, "posix"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Drift in the header alone is detected
+			{
+				PreConfig: func() {
+					_ = os.WriteFile(path, []byte("# Edited by hand\n\nexport DB_PASSWORD='it'\\''s $ecret'\nexport DB_URL='postgres://db:5432/app'\n"), 0o600)
+				},
+				Config:             testAccEnvFileResourceConfig(path, "posix"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccEnvFileResourceConfig(path, "powershell"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckEnvFileContent(path, "# Managed by terraform\n\n$env:DB_PASSWORD = 'it''s $ecret'\n$env:DB_URL = 'postgres://db:5432/app'\n"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccEnvFileResourceInvalidName(t *testing.T) {
+	path := filepath.ToSlash(filepath.Join(t.TempDir(), "stack.env"))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "localos_env_file" "test" {
+  path      = "%s"
+  variables = { "DB-URL" = "x" }
+}
+`, path),
+				ExpectError: regexp.MustCompile(`Invalid variable name`),
+			},
+		},
+	})
+}
+
+func testAccEnvFileResourceConfig(path, format string) string {
+	return fmt.Sprintf(`
+resource "localos_env_file" "test" {
+  path   = "%s"
+  format = "%s"
+  header = "Managed by terraform"
+
+  variables = {
+    DB_URL      = "postgres://db:5432/app"
+    DB_PASSWORD = "it's $ecret"
+  }
+}
+`, path, format)
+}
+
+func testAccCheckEnvFileContent(path, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		b, err := os.ReadFile(path)
+
+		if err != nil {
+			return err
+		}
+
+		if string(b) != expected {
+			return fmt.Errorf("unexpected content of %s:\n%s", path, b)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckEnvFileDeleted(path string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%s was not deleted", path)
+		}
+
+		return nil
+	}
+}
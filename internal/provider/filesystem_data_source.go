@@ -0,0 +1,359 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/mounts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &FilesystemDataSource{}
+
+func NewFilesystemDataSource() datasource.DataSource {
+	return &FilesystemDataSource{}
+}
+
+// FilesystemDataSource defines the data source implementation.
+type FilesystemDataSource struct {
+}
+
+// FilesystemDataSourceModel describes the data source data model.
+type FilesystemDataSourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	Path           types.String `tfsdk:"path"`
+	FsTypes        types.List   `tfsdk:"fs_types"`
+	ExcludeFsTypes types.List   `tfsdk:"exclude_fs_types"`
+	ResolvedPath   types.String `tfsdk:"resolved_path"`
+	MountPoint     types.String `tfsdk:"mount_point"`
+	Device         types.String `tfsdk:"device"`
+	FsType         types.String `tfsdk:"fs_type"`
+	Options        types.List   `tfsdk:"options"`
+	ReadOnly       types.Bool   `tfsdk:"read_only"`
+	TotalBytes     types.Int64  `tfsdk:"total_bytes"`
+	FreeBytes      types.Int64  `tfsdk:"free_bytes"`
+	AvailableBytes types.Int64  `tfsdk:"available_bytes"`
+	UsedBytes      types.Int64  `tfsdk:"used_bytes"`
+	InodesTotal    types.Int64  `tfsdk:"inodes_total"`
+	InodesFree     types.Int64  `tfsdk:"inodes_free"`
+	Mounts         types.List   `tfsdk:"mounts"` //< MountModel
+}
+
+// MountModel describes a mounted filesystem.
+type MountModel struct {
+	MountPoint     types.String `tfsdk:"mount_point"`
+	Device         types.String `tfsdk:"device"`
+	FsType         types.String `tfsdk:"fs_type"`
+	Options        types.List   `tfsdk:"options"`
+	ReadOnly       types.Bool   `tfsdk:"read_only"`
+	TotalBytes     types.Int64  `tfsdk:"total_bytes"`
+	FreeBytes      types.Int64  `tfsdk:"free_bytes"`
+	AvailableBytes types.Int64  `tfsdk:"available_bytes"`
+	UsedBytes      types.Int64  `tfsdk:"used_bytes"`
+	InodesTotal    types.Int64  `tfsdk:"inodes_total"`
+	InodesFree     types.Int64  `tfsdk:"inodes_free"`
+}
+
+func (d *FilesystemDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_filesystem"
+}
+
+func (d *FilesystemDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "The `filesystem` data source gets the filesystem that holds a path, with its free space, " +
+			"or lists all mounted filesystems. Supported on Linux, macOS, FreeBSD and Windows.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier",
+				Computed:            true,
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Path to get the filesystem of. If the path does not exist, its nearest existing parent directory is used, " +
+					"as that is where it would be created. If not set, all filesystems are listed in `mounts` and the other attributes are null.",
+				Optional: true,
+			},
+			"fs_types": schema.ListAttribute{
+				MarkdownDescription: "If set, only list filesystems of these types, e.g. `[\"ext4\", \"xfs\"]`. Not used when `path` is set.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"exclude_fs_types": schema.ListAttribute{
+				MarkdownDescription: "Do not list filesystems of these types, e.g. `[\"tmpfs\", \"overlay\", \"squashfs\"]`. Not used when `path` is set.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"resolved_path": schema.StringAttribute{
+				MarkdownDescription: "Absolute path, with symbolic links resolved, of `path` or its nearest existing parent.",
+				Computed:            true,
+			},
+			"mount_point": schema.StringAttribute{
+				MarkdownDescription: "Directory where the filesystem is mounted, e.g. `/home`, or on Windows the root of the volume, e.g. `C:\\`.",
+				Computed:            true,
+			},
+			"device": schema.StringAttribute{
+				MarkdownDescription: "Source of the filesystem, e.g. `/dev/sda1`, `server:/export` or `tmpfs`, or on Windows the volume GUID path.",
+				Computed:            true,
+			},
+			"fs_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the filesystem, e.g. `ext4`, `apfs` or `NTFS`.",
+				Computed:            true,
+			},
+			"options": schema.ListAttribute{
+				MarkdownDescription: "Mount options, e.g. `rw`, `noatime`. On systems other than Linux, only `ro` or `rw`, `nosuid`, `noexec` and `remote` are reported.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "True if the filesystem is mounted read-only.",
+				Computed:            true,
+			},
+			"total_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Size of the filesystem.",
+				Computed:            true,
+			},
+			"free_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Free space, including space reserved for the superuser.",
+				Computed:            true,
+			},
+			"available_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Free space available to the user running terraform. Use this to check there is room for e.g. an image download.",
+				Computed:            true,
+			},
+			"used_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Space in use.",
+				Computed:            true,
+			},
+			"inodes_total": schema.Int64Attribute{
+				MarkdownDescription: "Number of inodes. Null where the filesystem has no fixed number of inodes, e.g. btrfs, or on Windows.",
+				Computed:            true,
+			},
+			"inodes_free": schema.Int64Attribute{
+				MarkdownDescription: "Number of free inodes. Null when `inodes_total` is null.",
+				Computed:            true,
+			},
+			"mounts": schema.ListAttribute{
+				MarkdownDescription: "Mounted filesystems, in the order they were mounted. When `path` is set, just the filesystem that holds it.",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: mountAttributeTypes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *FilesystemDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Nothing to configure
+}
+
+func mountAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"mount_point":     types.StringType,
+		"device":          types.StringType,
+		"fs_type":         types.StringType,
+		"options":         types.ListType{ElemType: types.StringType},
+		"read_only":       types.BoolType,
+		"total_bytes":     types.Int64Type,
+		"free_bytes":      types.Int64Type,
+		"available_bytes": types.Int64Type,
+		"used_bytes":      types.Int64Type,
+		"inodes_total":    types.Int64Type,
+		"inodes_free":     types.Int64Type,
+	}
+}
+
+func (d *FilesystemDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FilesystemDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var fsTypes, excludeFsTypes []string
+	resp.Diagnostics.Append(data.FsTypes.ElementsAs(ctx, &fsTypes, false)...)
+	resp.Diagnostics.Append(data.ExcludeFsTypes.ElementsAs(ctx, &excludeFsTypes, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mountModels := make([]MountModel, 0, 16)
+	data.ResolvedPath = types.StringNull()
+
+	switch {
+	case data.Path.IsNull():
+		data.Id = types.StringValue("mounts")
+		list, err := mounts.List()
+
+		if errors.Is(err, mounts.ErrNotSupported) {
+			resp.Diagnostics.AddWarning("Filesystems are not available", fmt.Sprintf("Reading filesystems is not supported on %s", runtime.GOOS))
+			break
+		}
+
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to read mounts", err.Error())
+			return
+		}
+
+		for i := range list {
+			m := &list[i]
+
+			if (len(fsTypes) > 0 && !containsFold(fsTypes, m.FSType)) || containsFold(excludeFsTypes, m.FSType) {
+				continue
+			}
+
+			// Usage is not available for some mounts, e.g. those of other users under /run/user
+			usage, _ := mounts.GetUsage(m.MountPoint)
+			model, diags := mountToMountModel(ctx, m, usage)
+			resp.Diagnostics.Append(diags...)
+			mountModels = append(mountModels, model)
+		}
+
+	default:
+		data.Id = data.Path
+		resolved, err := mounts.Resolve(data.Path.ValueString())
+
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to resolve path", err.Error())
+			return
+		}
+
+		m, err := mounts.Find(resolved)
+
+		if errors.Is(err, mounts.ErrNotSupported) {
+			resp.Diagnostics.AddWarning("Filesystems are not available", fmt.Sprintf("Reading filesystems is not supported on %s", runtime.GOOS))
+			break
+		}
+
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to find filesystem", err.Error())
+			return
+		}
+
+		usage, err := mounts.GetUsage(resolved)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to read filesystem usage", err.Error())
+			return
+		}
+
+		model, diags := mountToMountModel(ctx, m, usage)
+		resp.Diagnostics.Append(diags...)
+		mountModels = append(mountModels, model)
+		data.ResolvedPath = types.StringValue(resolved)
+	}
+
+	data.setMount(mountModels)
+
+	resp.Diagnostics.Append(tfsdk.ValueFrom(ctx, mountModels, types.ListType{
+		ElemType: types.ObjectType{
+			AttrTypes: mountAttributeTypes(),
+		},
+	}, &data.Mounts)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Read filesystem data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// setMount sets the top level attributes from the mount found for path, or to null when listing mounts.
+func (m *FilesystemDataSourceModel) setMount(mountModels []MountModel) {
+	mount := MountModel{
+		MountPoint:     types.StringNull(),
+		Device:         types.StringNull(),
+		FsType:         types.StringNull(),
+		Options:        types.ListNull(types.StringType),
+		ReadOnly:       types.BoolNull(),
+		TotalBytes:     types.Int64Null(),
+		FreeBytes:      types.Int64Null(),
+		AvailableBytes: types.Int64Null(),
+		UsedBytes:      types.Int64Null(),
+		InodesTotal:    types.Int64Null(),
+		InodesFree:     types.Int64Null(),
+	}
+
+	if !m.Path.IsNull() && len(mountModels) == 1 {
+		mount = mountModels[0]
+	}
+
+	m.MountPoint = mount.MountPoint
+	m.Device = mount.Device
+	m.FsType = mount.FsType
+	m.Options = mount.Options
+	m.ReadOnly = mount.ReadOnly
+	m.TotalBytes = mount.TotalBytes
+	m.FreeBytes = mount.FreeBytes
+	m.AvailableBytes = mount.AvailableBytes
+	m.UsedBytes = mount.UsedBytes
+	m.InodesTotal = mount.InodesTotal
+	m.InodesFree = mount.InodesFree
+}
+
+// mountToMountModel converts a mount and its usage, which may be nil if it could not be read.
+func mountToMountModel(ctx context.Context, m *mounts.Mount, usage *mounts.Usage) (MountModel, diag.Diagnostics) {
+	model := MountModel{
+		MountPoint:     types.StringValue(m.MountPoint),
+		Device:         optionalString(m.Device),
+		FsType:         types.StringValue(m.FSType),
+		ReadOnly:       types.BoolValue(containsFold(m.Options, "ro")),
+		TotalBytes:     types.Int64Null(),
+		FreeBytes:      types.Int64Null(),
+		AvailableBytes: types.Int64Null(),
+		UsedBytes:      types.Int64Null(),
+		InodesTotal:    types.Int64Null(),
+		InodesFree:     types.Int64Null(),
+	}
+
+	if usage != nil {
+		model.TotalBytes = types.Int64Value(int64(usage.TotalBytes))
+		model.FreeBytes = types.Int64Value(int64(usage.FreeBytes))
+		model.AvailableBytes = types.Int64Value(int64(usage.AvailableBytes))
+		model.UsedBytes = types.Int64Value(int64(usage.UsedBytes()))
+
+		if usage.Inodes > 0 {
+			model.InodesTotal = types.Int64Value(int64(usage.Inodes))
+			model.InodesFree = types.Int64Value(int64(usage.InodesFree))
+		}
+	}
+
+	var diags diag.Diagnostics
+	model.Options, diags = types.ListValueFrom(ctx, types.StringType, m.Options)
+
+	return model, diags
+}
+
+// containsFold reports whether list contains s, ignoring case, as Windows reports e.g. "NTFS".
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+
+	return false
+}
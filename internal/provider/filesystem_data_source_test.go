@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFilesystemDataSource(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" && runtime.GOOS != "windows" {
+		t.Skip("filesystems are not supported on", runtime.GOOS)
+	}
+
+	dir := t.TempDir()
+	missing := filepath.ToSlash(filepath.Join(dir, "does", "not", "exist"))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: fmt.Sprintf(`data "localos_filesystem" "test" {
+  path = %q
+}`, missing),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.localos_filesystem.test", "id", missing),
+					resource.TestCheckResourceAttrSet("data.localos_filesystem.test", "resolved_path"),
+					resource.TestCheckResourceAttrSet("data.localos_filesystem.test", "mount_point"),
+					resource.TestCheckResourceAttrSet("data.localos_filesystem.test", "fs_type"),
+					resource.TestCheckResourceAttrSet("data.localos_filesystem.test", "total_bytes"),
+					resource.TestCheckResourceAttrSet("data.localos_filesystem.test", "available_bytes"),
+					resource.TestCheckResourceAttr("data.localos_filesystem.test", "read_only", "false"),
+					resource.TestCheckResourceAttr("data.localos_filesystem.test", "mounts.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFilesystemDataSourceList(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("mount types are only known on linux")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: `data "localos_filesystem" "test" {
+  fs_types = ["proc"]
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.localos_filesystem.test", "id", "mounts"),
+					resource.TestCheckNoResourceAttr("data.localos_filesystem.test", "mount_point"),
+					resource.TestCheckResourceAttr("data.localos_filesystem.test", "mounts.0.fs_type", "proc"),
+					resource.TestCheckResourceAttr("data.localos_filesystem.test", "mounts.0.mount_point", "/proc"),
+				),
+			},
+		},
+	})
+}
@@ -43,7 +43,7 @@ func (d *FoldersDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				MarkdownDescription: "Resource identifier",
+				MarkdownDescription: "Resource identifier, which identifies the machine. The same as `machine_id` of `localos_machine` with the default `application_id`.",
 				Computed:            true,
 			},
 			"home": schema.StringAttribute{
@@ -0,0 +1,241 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"runtime"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/dmi"
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/machineid"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &HardwareDataSource{}
+
+func NewHardwareDataSource() datasource.DataSource {
+	return &HardwareDataSource{}
+}
+
+// HardwareDataSource defines the data source implementation.
+type HardwareDataSource struct {
+}
+
+// HardwareDataSourceModel describes the data source data model.
+type HardwareDataSourceModel struct {
+	Id                 types.String `tfsdk:"id"`
+	IncludeIdentifiers types.Bool   `tfsdk:"include_identifiers"`
+	HashIdentifiers    types.Bool   `tfsdk:"hash_identifiers"`
+	SystemVendor       types.String `tfsdk:"system_vendor"`
+	ProductName        types.String `tfsdk:"product_name"`
+	ProductVersion     types.String `tfsdk:"product_version"`
+	ProductFamily      types.String `tfsdk:"product_family"`
+	BoardVendor        types.String `tfsdk:"board_vendor"`
+	BoardName          types.String `tfsdk:"board_name"`
+	BoardVersion       types.String `tfsdk:"board_version"`
+	BiosVendor         types.String `tfsdk:"bios_vendor"`
+	BiosVersion        types.String `tfsdk:"bios_version"`
+	BiosDate           types.String `tfsdk:"bios_date"`
+	ChassisVendor      types.String `tfsdk:"chassis_vendor"`
+	ChassisType        types.Int64  `tfsdk:"chassis_type"`
+	ChassisTypeName    types.String `tfsdk:"chassis_type_name"`
+	ChassisClass       types.String `tfsdk:"chassis_class"`
+	SerialNumber       types.String `tfsdk:"serial_number"`
+	Uuid               types.String `tfsdk:"uuid"`
+}
+
+func (d *HardwareDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hardware"
+}
+
+func (d *HardwareDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "The `hardware` data source gets the make, model, firmware and chassis type of the machine that is running terraform, " +
+			"from the SMBIOS tables exported by Linux in `/sys/class/dmi/id`. Values the vendor left as placeholders, e.g. `To Be Filled By O.E.M.`, are null. " +
+			"Only available on Linux, on machines with SMBIOS, which includes most x86 machines and VMs, but few ARM boards.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier",
+				Computed:            true,
+			},
+			"include_identifiers": schema.BoolAttribute{
+				MarkdownDescription: "Whether to read `serial_number` and `uuid`. Default `false`. " +
+					"Linux only allows root to read them, so they are null for other users.",
+				Optional: true,
+			},
+			"hash_identifiers": schema.BoolAttribute{
+				MarkdownDescription: "Whether `serial_number` and `uuid` are returned as the hex HMAC-SHA256 of their values, " +
+					"keyed by the `machine_id` of `localos_machine`, so that machines can be told apart without putting their identifiers in the state. " +
+					"The hashes cannot be reversed, even by trying likely serial numbers, without the machine ID, " +
+					"and do not match those made on other machines or by other applications. " +
+					"Only a machine ID of the system is used, so hashes are the same for every user. " +
+					"Where there is none, and `localos_machine` would generate one, `serial_number` and `uuid` are null with a warning. Default `true`.",
+				Optional: true,
+			},
+			"system_vendor": schema.StringAttribute{
+				MarkdownDescription: "Manufacturer of the machine, e.g. `Dell Inc.`, `LENOVO`, `QEMU`.",
+				Computed:            true,
+			},
+			"product_name": schema.StringAttribute{
+				MarkdownDescription: "Model of the machine, e.g. `PowerEdge R640`. Lenovo gives the machine type here, e.g. `20KH006MUK`, and the model in `product_version`.",
+				Computed:            true,
+			},
+			"product_version": schema.StringAttribute{
+				MarkdownDescription: "Version of the model, e.g. `ThinkPad X1 Carbon 6th`.",
+				Computed:            true,
+			},
+			"product_family": schema.StringAttribute{
+				MarkdownDescription: "Family of the model, e.g. `ThinkPad X1 Carbon 6th`, `PowerEdge`.",
+				Computed:            true,
+			},
+			"board_vendor": schema.StringAttribute{
+				MarkdownDescription: "Manufacturer of the mainboard.",
+				Computed:            true,
+			},
+			"board_name": schema.StringAttribute{
+				MarkdownDescription: "Model of the mainboard.",
+				Computed:            true,
+			},
+			"board_version": schema.StringAttribute{
+				MarkdownDescription: "Revision of the mainboard.",
+				Computed:            true,
+			},
+			"bios_vendor": schema.StringAttribute{
+				MarkdownDescription: "Vendor of the BIOS or UEFI firmware, e.g. `American Megatrends Inc.`.",
+				Computed:            true,
+			},
+			"bios_version": schema.StringAttribute{
+				MarkdownDescription: "Version of the firmware.",
+				Computed:            true,
+			},
+			"bios_date": schema.StringAttribute{
+				MarkdownDescription: "Release date of the firmware as `YYYY-MM-DD`, or as given if it is not a valid date.",
+				Computed:            true,
+			},
+			"chassis_vendor": schema.StringAttribute{
+				MarkdownDescription: "Manufacturer of the chassis.",
+				Computed:            true,
+			},
+			"chassis_type": schema.Int64Attribute{
+				MarkdownDescription: "SMBIOS chassis type, e.g. 3 for desktop, 10 for notebook, 23 for rack mount.",
+				Computed:            true,
+			},
+			"chassis_type_name": schema.StringAttribute{
+				MarkdownDescription: "SMBIOS name of `chassis_type`, e.g. `Desktop`, `Notebook`, `Rack Mount Chassis`.",
+				Computed:            true,
+			},
+			"chassis_class": schema.StringAttribute{
+				MarkdownDescription: "Kind of machine given by `chassis_type`, as reported by `hostnamectl`: `desktop`, `laptop`, `convertible`, `server`, `tablet`, `handset` or `embedded`. " +
+					"Null for types that do not say, such as `Other`, which most VMs report.",
+				Computed: true,
+			},
+			"serial_number": schema.StringAttribute{
+				MarkdownDescription: "Serial number of the machine, or its hash. Null unless `include_identifiers` is true and terraform runs as root.",
+				Computed:            true,
+			},
+			"uuid": schema.StringAttribute{
+				MarkdownDescription: "SMBIOS UUID of the machine in lower case, or its hash. Null unless `include_identifiers` is true and terraform runs as root.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *HardwareDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Nothing to configure
+}
+
+func (d *HardwareDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HardwareDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info := &dmi.Info{}
+
+	if runtime.GOOS != "linux" {
+		resp.Diagnostics.AddWarning("Hardware details are not available", fmt.Sprintf("Reading hardware details is not supported on %s", runtime.GOOS))
+	} else if i, err := dmi.Read(dmi.DefaultSysRoot, data.IncludeIdentifiers.ValueBool()); errors.Is(err, dmi.ErrNotSupported) {
+		resp.Diagnostics.AddWarning("Hardware details are not available", err.Error())
+	} else if err != nil {
+		resp.Diagnostics.AddWarning("Unable to read hardware details", err.Error())
+	} else {
+		info = i
+	}
+
+	if (data.HashIdentifiers.IsNull() || data.HashIdentifiers.ValueBool()) && (info.Serial != "" || info.UUID != "") {
+		key, err := hardwareHashKey()
+
+		if err != nil {
+			// Not returned unhashed, as that was not asked for
+			resp.Diagnostics.AddWarning("Unable to hash hardware identifiers", err.Error())
+			info.Serial, info.UUID = "", ""
+		} else {
+			info.Serial = dmi.Hash(key, info.Serial)
+			info.UUID = dmi.Hash(key, info.UUID)
+		}
+	}
+
+	data.Id = types.StringValue("hardware")
+	data.SystemVendor = optionalString(info.SystemVendor)
+	data.ProductName = optionalString(info.ProductName)
+	data.ProductVersion = optionalString(info.ProductVersion)
+	data.ProductFamily = optionalString(info.ProductFamily)
+	data.BoardVendor = optionalString(info.BoardVendor)
+	data.BoardName = optionalString(info.BoardName)
+	data.BoardVersion = optionalString(info.BoardVersion)
+	data.BiosVendor = optionalString(info.BIOSVendor)
+	data.BiosVersion = optionalString(info.BIOSVersion)
+	data.BiosDate = optionalString(info.BIOSDate)
+	data.ChassisVendor = optionalString(info.ChassisVendor)
+	data.ChassisType = optionalInt64(int64(info.ChassisType))
+	data.ChassisTypeName = optionalString(dmi.ChassisTypeName(info.ChassisType))
+	data.ChassisClass = optionalString(dmi.ChassisClass(info.ChassisType))
+	data.SerialNumber = optionalString(info.Serial)
+	data.Uuid = optionalString(info.UUID)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Read hardware data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// hardwareHashKey gets the key identifiers are hashed with, the ID of the machine for this
+// provider, which is the machine_id of localos_machine. Hashes then differ between machines
+// and from those made by other applications, and cannot be reversed without the machine ID.
+// The fallback ID is neither read nor generated, as it is per user, so a data source would
+// write state and the same serial would hash differently for each user or CI runner.
+func hardwareHashKey() ([]byte, error) {
+	paths := machineid.DefaultPaths()
+	paths.Fallback = ""
+
+	identity, err := machineid.Read(paths)
+
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := machineid.AppSpecific(identity.MachineID, machineid.ProviderAppID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return hex.DecodeString(id)
+}
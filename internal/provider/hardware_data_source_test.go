@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHardwareDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: `data "localos_hardware" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.localos_hardware.test", "id", "hardware"),
+					// Identifiers are opt-in
+					resource.TestCheckNoResourceAttr("data.localos_hardware.test", "serial_number"),
+					resource.TestCheckNoResourceAttr("data.localos_hardware.test", "uuid"),
+				),
+			},
+		},
+	})
+}
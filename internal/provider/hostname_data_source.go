@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/hostname"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &HostnameDataSource{}
+
+func NewHostnameDataSource() datasource.DataSource {
+	return &HostnameDataSource{}
+}
+
+// HostnameDataSource defines the data source implementation.
+type HostnameDataSource struct {
+}
+
+// HostnameDataSourceModel describes the data source data model.
+type HostnameDataSourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	Hostname  types.String `tfsdk:"hostname"`
+	ShortName types.String `tfsdk:"short_name"`
+	FQDN      types.String `tfsdk:"fqdn"`
+	Domain    types.String `tfsdk:"domain"`
+	Nodename  types.String `tfsdk:"nodename"`
+}
+
+func (d *HostnameDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hostname"
+}
+
+func (d *HostnameDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "The `hostname` data source gets the name of the machine that is running terraform. " +
+			"Unlike `$HOSTNAME` or `%COMPUTERNAME%`, this does not depend on the environment of the shell running terraform.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier",
+				Computed:            true,
+			},
+			"hostname": schema.StringAttribute{
+				MarkdownDescription: "Host name as reported by the kernel",
+				Computed:            true,
+			},
+			"short_name": schema.StringAttribute{
+				MarkdownDescription: "Host name up to the first dot",
+				Computed:            true,
+			},
+			"fqdn": schema.StringAttribute{
+				MarkdownDescription: "Fully qualified domain name, found in the same way as `hostname -f` using the hosts file and DNS. " +
+					"If no fully qualified name can be found, this is the same as `hostname`.",
+				Computed: true,
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "DNS domain, being `fqdn` after the first dot. Empty if the FQDN could not be determined.",
+				Computed:            true,
+			},
+			"nodename": schema.StringAttribute{
+				MarkdownDescription: "Node name from `uname`. On Windows this is the same as `hostname`.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *HostnameDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Nothing to configure
+}
+
+func (d *HostnameDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HostnameDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, err := hostname.Get(ctx, net.DefaultResolver)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get hostname", err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(info.FQDN)
+	data.Hostname = types.StringValue(info.Hostname)
+	data.ShortName = types.StringValue(info.ShortName)
+	data.FQDN = types.StringValue(info.FQDN)
+	data.Domain = types.StringValue(info.Domain)
+	data.Nodename = types.StringValue(info.Nodename)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Read hostname data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHostnameDataSource(t *testing.T) {
+	expectedHostname, err := os.Hostname()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedShortName := strings.SplitN(expectedHostname, ".", 2)[0]
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: `data "localos_hostname" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.localos_hostname.test", "hostname", expectedHostname),
+					resource.TestCheckResourceAttr("data.localos_hostname.test", "short_name", expectedShortName),
+					resource.TestCheckResourceAttrWith("data.localos_hostname.test", "fqdn", func(value string) error {
+						if !strings.HasPrefix(strings.ToLower(value), strings.ToLower(expectedShortName)) {
+							return fmt.Errorf("FQDN %s does not start with %s", value, expectedShortName)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttrSet("data.localos_hostname.test", "nodename"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/hostsfile"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &HostsDataSource{}
+
+func NewHostsDataSource() datasource.DataSource {
+	return &HostsDataSource{}
+}
+
+// HostsDataSource defines the data source implementation.
+type HostsDataSource struct {
+}
+
+// HostsDataSourceModel describes the data source data model.
+type HostsDataSourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	Path            types.String `tfsdk:"path"`
+	Entries         types.List   `tfsdk:"entries"` //< HostsEntryModel
+	AddressesByName types.Map    `tfsdk:"addresses_by_name"`
+	NamesByAddress  types.Map    `tfsdk:"names_by_address"`
+}
+
+type HostsEntryModel struct {
+	Address       types.String `tfsdk:"address"`
+	CanonicalName types.String `tfsdk:"canonical_name"`
+	Aliases       types.List   `tfsdk:"aliases"`
+	Comment       types.String `tfsdk:"comment"`
+}
+
+func (d *HostsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hosts"
+}
+
+func (d *HostsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "`hosts` data source gets the entries of the hosts file on the machine that reads the data source.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier",
+				Computed:            true,
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Path to the hosts file. Default is `/etc/hosts`, or `%SystemRoot%\\System32\\drivers\\etc\\hosts` on Windows.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"entries": schema.ListAttribute{
+				MarkdownDescription: "Entries in the order they appear in the file",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: hostsEntryAttributeTypes(),
+				},
+			},
+			"addresses_by_name": schema.MapAttribute{
+				MarkdownDescription: "Map of lower cased host name to the addresses given for that name",
+				Computed:            true,
+				ElementType: types.ListType{
+					ElemType: types.StringType,
+				},
+			},
+			"names_by_address": schema.MapAttribute{
+				MarkdownDescription: "Map of address to the names given for that address",
+				Computed:            true,
+				ElementType: types.ListType{
+					ElemType: types.StringType,
+				},
+			},
+		},
+	}
+}
+
+func (d *HostsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Nothing to configure
+}
+
+func hostsEntryAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"address":        types.StringType,
+		"canonical_name": types.StringType,
+		"aliases": types.ListType{
+			ElemType: types.StringType,
+		},
+		"comment": types.StringType,
+	}
+}
+
+func (d *HostsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HostsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Path.IsNull() {
+		data.Path = types.StringValue(hostsfile.DefaultPath())
+	}
+
+	entries, err := hostsfile.Read(data.Path.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read hosts file", err.Error())
+		return
+	}
+
+	entryModels := make([]HostsEntryModel, 0, len(entries))
+
+	for _, e := range entries {
+		aliases, diags := types.ListValueFrom(ctx, types.StringType, e.Aliases)
+		resp.Diagnostics.Append(diags...)
+
+		entryModels = append(entryModels, HostsEntryModel{
+			Address:       types.StringValue(e.Address),
+			CanonicalName: types.StringValue(e.CanonicalName),
+			Aliases:       aliases,
+			Comment:       types.StringValue(e.Comment),
+		})
+	}
+
+	resp.Diagnostics.Append(tfsdk.ValueFrom(ctx, entryModels, types.ListType{
+		ElemType: types.ObjectType{
+			AttrTypes: hostsEntryAttributeTypes(),
+		},
+	}, &data.Entries)...)
+
+	lookupType := types.MapType{
+		ElemType: types.ListType{
+			ElemType: types.StringType,
+		},
+	}
+
+	resp.Diagnostics.Append(tfsdk.ValueFrom(ctx, hostsfile.AddressesByName(entries), lookupType, &data.AddressesByName)...)
+	resp.Diagnostics.Append(tfsdk.ValueFrom(ctx, hostsfile.NamesByAddress(entries), lookupType, &data.NamesByAddress)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = data.Path
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Read hosts data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testHostsFile = `127.0.0.1 localhost
+# Local cluster ingress
+192.168.49.2 app.dev.local api.dev.local # minikube
+`
+
+func TestAccHostsDataSource(t *testing.T) {
+	path := filepath.ToSlash(filepath.Join(t.TempDir(), "hosts"))
+
+	if err := os.WriteFile(path, []byte(testHostsFile), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: fmt.Sprintf(`data "localos_hosts" "test" { path = "%s" }`, path),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.localos_hosts.test", "id", path),
+					resource.TestCheckResourceAttr("data.localos_hosts.test", "entries.#", "2"),
+					resource.TestCheckResourceAttr("data.localos_hosts.test", "entries.1.address", "192.168.49.2"),
+					resource.TestCheckResourceAttr("data.localos_hosts.test", "entries.1.canonical_name", "app.dev.local"),
+					resource.TestCheckResourceAttr("data.localos_hosts.test", "entries.1.aliases.0", "api.dev.local"),
+					resource.TestCheckResourceAttr("data.localos_hosts.test", "entries.1.comment", "minikube"),
+					resource.TestCheckResourceAttr("data.localos_hosts.test", "addresses_by_name.api.dev.local.0", "192.168.49.2"),
+					resource.TestCheckResourceAttr("data.localos_hosts.test", "names_by_address.127.0.0.1.0", "localhost"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccHostsDataSourceMissingFile(t *testing.T) {
+	path := filepath.ToSlash(filepath.Join(t.TempDir(), "missing"))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(`data "localos_hosts" "test" { path = "%s" }`, path),
+				ExpectError: regexp.MustCompile(`Unable to read hosts file`),
+			},
+		},
+	})
+}
@@ -0,0 +1,417 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/fileutil"
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/hostsfile"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Mode given to the hosts file if it does not yet exist.
+const defaultHostsFileMode = 0o644
+
+var hostsEntryNameRegex = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &HostsEntryResource{}
+	_ resource.ResourceWithImportState    = &HostsEntryResource{}
+	_ resource.ResourceWithValidateConfig = &HostsEntryResource{}
+)
+
+func NewHostsEntryResource() resource.Resource {
+	return &HostsEntryResource{}
+}
+
+// HostsEntryResource defines the resource implementation.
+type HostsEntryResource struct {
+}
+
+// HostsEntryResourceModel describes the resource data model.
+type HostsEntryResourceModel struct {
+	Id      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Path    types.String `tfsdk:"path"`
+	Entries types.List   `tfsdk:"entries"` //< HostsEntryLineModel
+}
+
+type HostsEntryLineModel struct {
+	Address   types.String `tfsdk:"address"`
+	Hostnames types.List   `tfsdk:"hostnames"`
+}
+
+func (r *HostsEntryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hosts_entry"
+}
+
+func (r *HostsEntryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "`hosts_entry` resource manages a block of lines in the hosts file of the machine that is running terraform. " +
+			"The block is delimited by `# BEGIN localos_hosts_entry <name>` and `# END localos_hosts_entry <name>` comments, " +
+			"and all other content of the file is left as it is. Terraform must have permission to write the file and create files in its directory. " +
+			"While the file is updated, it is locked by creating `<path>.lock`, e.g. `/etc/hosts.lock`, which is removed afterwards. " +
+			"If terraform is killed while updating the file, the lock file is left behind, and must be deleted before the file can be updated again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier, `<name>@<path>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the block. Must be unique within the hosts file and may contain only letters, digits, `_`, `.` and `-`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Path to the hosts file. Default is `/etc/hosts`, or `%SystemRoot%\\System32\\drivers\\etc\\hosts` on Windows.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(hostsfile.DefaultPath()),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"entries": schema.ListAttribute{
+				MarkdownDescription: "Lines to write in the block, in order",
+				Required:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: hostsEntryLineAttributeTypes(),
+				},
+			},
+		},
+	}
+}
+
+func (r *HostsEntryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Nothing to configure
+}
+
+func hostsEntryLineAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"address": types.StringType,
+		"hostnames": types.ListType{
+			ElemType: types.StringType,
+		},
+	}
+}
+
+func (r *HostsEntryResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data HostsEntryResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Name.IsUnknown() && !data.Name.IsNull() && !hostsEntryNameRegex.MatchString(data.Name.ValueString()) {
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Invalid name",
+			fmt.Sprintf("%q may contain only letters, digits, '_', '.' and '-'", data.Name.ValueString()))
+	}
+
+	if data.Entries.IsUnknown() || data.Entries.IsNull() {
+		return
+	}
+
+	var lines []HostsEntryLineModel
+
+	resp.Diagnostics.Append(data.Entries.ElementsAs(ctx, &lines, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, line := range lines {
+		attrPath := path.Root("entries").AtListIndex(i)
+
+		if !line.Address.IsUnknown() && net.ParseIP(line.Address.ValueString()) == nil {
+			resp.Diagnostics.AddAttributeError(attrPath.AtName("address"), "Invalid address",
+				fmt.Sprintf("%q is not an IP address", line.Address.ValueString()))
+		}
+
+		if line.Hostnames.IsUnknown() {
+			continue
+		}
+
+		var hostnames []types.String
+
+		resp.Diagnostics.Append(line.Hostnames.ElementsAs(ctx, &hostnames, false)...)
+
+		if len(hostnames) == 0 {
+			resp.Diagnostics.AddAttributeError(attrPath.AtName("hostnames"), "Missing host names",
+				"At least one host name is required")
+		}
+
+		for _, h := range hostnames {
+			if !h.IsUnknown() && (h.ValueString() == "" || strings.ContainsAny(h.ValueString(), "# \t\r\n")) {
+				resp.Diagnostics.AddAttributeError(attrPath.AtName("hostnames"), "Invalid host name",
+					fmt.Sprintf("%q is not a valid host name", h.ValueString()))
+			}
+		}
+	}
+}
+
+func (r *HostsEntryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data HostsEntryResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries, diags := hostsEntriesFromModel(ctx, data.Entries)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	err := updateHostsFile(data.Path.ValueString(), func(content []byte) ([]byte, error) {
+		if _, found, err := hostsfile.ReadBlock(content, name); err != nil {
+			return nil, err
+		} else if found {
+			return nil, fmt.Errorf("a block named %q already exists. Import it, or choose another name", name)
+		}
+
+		return hostsfile.SetBlock(content, name, entries)
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to update hosts file", err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(name + "@" + data.Path.ValueString())
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Created hosts_entry resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HostsEntryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data HostsEntryResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	content, err := os.ReadFile(data.Path.ValueString())
+
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		resp.Diagnostics.AddError("Unable to read hosts file", err.Error())
+		return
+	}
+
+	entries, found, err := hostsfile.ReadBlock(content, data.Name.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read hosts file", err.Error())
+		return
+	}
+
+	if !found {
+		// Block has been removed outside of terraform
+		tflog.Info(ctx, "Block not found in hosts file, removing from state", map[string]any{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	lines := make([]HostsEntryLineModel, 0, len(entries))
+
+	for _, e := range entries {
+		hostnames, diags := types.ListValueFrom(ctx, types.StringType, e.Names())
+		resp.Diagnostics.Append(diags...)
+
+		lines = append(lines, HostsEntryLineModel{
+			Address:   types.StringValue(e.Address),
+			Hostnames: hostnames,
+		})
+	}
+
+	resp.Diagnostics.Append(tfsdk.ValueFrom(ctx, lines, types.ListType{
+		ElemType: types.ObjectType{
+			AttrTypes: hostsEntryLineAttributeTypes(),
+		},
+	}, &data.Entries)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Read hosts_entry resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HostsEntryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data HostsEntryResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries, diags := hostsEntriesFromModel(ctx, data.Entries)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := updateHostsFile(data.Path.ValueString(), func(content []byte) ([]byte, error) {
+		return hostsfile.SetBlock(content, data.Name.ValueString(), entries)
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to update hosts file", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, "Updated hosts_entry resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HostsEntryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data HostsEntryResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := updateHostsFile(data.Path.ValueString(), func(content []byte) ([]byte, error) {
+		if _, found, err := hostsfile.ReadBlock(content, data.Name.ValueString()); err != nil || !found {
+			// Leave the file untouched if the block is already gone, or has no END marker
+			return nil, err
+		}
+
+		return hostsfile.SetBlock(content, data.Name.ValueString(), nil)
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to update hosts file", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted hosts_entry resource")
+}
+
+// ImportState accepts either "<name>", for a block in the default hosts file, or "<name>@<path>".
+func (r *HostsEntryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, hostsPath, ok := strings.Cut(req.ID, "@")
+
+	if !ok {
+		hostsPath = hostsfile.DefaultPath()
+	}
+
+	if !hostsEntryNameRegex.MatchString(name) || hostsPath == "" {
+		resp.Diagnostics.AddError("Invalid import identifier",
+			fmt.Sprintf("Expected <name> or <name>@<path>, got %q", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), name+"@"+hostsPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("path"), hostsPath)...)
+}
+
+func hostsEntriesFromModel(ctx context.Context, list types.List) ([]*hostsfile.Entry, diag.Diagnostics) {
+	var lines []HostsEntryLineModel
+
+	diags := list.ElementsAs(ctx, &lines, false)
+	entries := make([]*hostsfile.Entry, 0, len(lines))
+
+	for _, line := range lines {
+		var hostnames []string
+
+		diags.Append(line.Hostnames.ElementsAs(ctx, &hostnames, false)...)
+
+		if len(hostnames) == 0 {
+			continue
+		}
+
+		entries = append(entries, &hostsfile.Entry{
+			Address:       line.Address.ValueString(),
+			CanonicalName: hostnames[0],
+			Aliases:       hostnames[1:],
+		})
+	}
+
+	return entries, diags
+}
+
+// updateHostsFile applies update to the content of the hosts file while holding its lock.
+// If update returns nil content, the file is not written.
+func updateHostsFile(hostsPath string, update func(content []byte) ([]byte, error)) error {
+	unlock, err := fileutil.Lock(hostsPath, fileutil.DefaultLockTimeout)
+
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	content, err := os.ReadFile(hostsPath)
+
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	mode, err := fileutil.Mode(hostsPath, defaultHostsFileMode)
+
+	if err != nil {
+		return err
+	}
+
+	updated, err := update(content)
+
+	if err != nil || updated == nil {
+		return err
+	}
+
+	// Rewritten in place, as in a container the hosts file is a bind mount that
+	// cannot be renamed over, and a new file would lose its SELinux label and ACLs
+	return fileutil.WriteInPlace(hostsPath, updated, mode)
+}
@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+const testHostsEntryUnmanaged = "127.0.0.1 localhost\n# keep me\n"
+
+func TestAccHostsEntryResource(t *testing.T) {
+	path := filepath.ToSlash(filepath.Join(t.TempDir(), "hosts"))
+
+	if err := os.WriteFile(path, []byte(testHostsEntryUnmanaged), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckHostsFileContent(path, testHostsEntryUnmanaged),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccHostsEntryResourceConfig(path, "192.168.49.2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("localos_hosts_entry.test", "id", "minikube@"+path),
+					resource.TestCheckResourceAttr("localos_hosts_entry.test", "entries.0.hostnames.1", "api.dev.local"),
+					testAccCheckHostsFileContent(path, testHostsEntryUnmanaged+
+						"# BEGIN localos_hosts_entry minikube\n"+
+						"192.168.49.2\tapp.dev.local api.dev.local\n"+
+						"# END localos_hosts_entry minikube\n"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "localos_hosts_entry.test",
+				ImportState:       true,
+				ImportStateId:     "minikube@" + path,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccHostsEntryResourceConfig(path, "192.168.49.3"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("localos_hosts_entry.test", "entries.0.address", "192.168.49.3"),
+				),
+			},
+			// Drift is detected and corrected
+			{
+				PreConfig: func() {
+					b, _ := os.ReadFile(path)
+					_ = os.WriteFile(path, []byte(strings.ReplaceAll(string(b), "192.168.49.3", "10.0.0.1")), 0o644)
+				},
+				Config:             testAccHostsEntryResourceConfig(path, "192.168.49.3"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccHostsEntryResourceConfig(path, "192.168.49.3"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("localos_hosts_entry.test", "entries.0.address", "192.168.49.3"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccHostsEntryResourceInvalidAddress(t *testing.T) {
+	path := filepath.ToSlash(filepath.Join(t.TempDir(), "hosts"))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccHostsEntryResourceConfig(path, "not-an-ip"),
+				ExpectError: regexp.MustCompile(`Invalid address`),
+			},
+		},
+	})
+}
+
+func testAccHostsEntryResourceConfig(path, address string) string {
+	return fmt.Sprintf(`
+resource "localos_hosts_entry" "test" {
+  name = "minikube"
+  path = "%s"
+
+  entries = [
+    {
+      address   = "%s"
+      hostnames = ["app.dev.local", "api.dev.local"]
+    },
+  ]
+}
+`, path, address)
+}
+
+func testAccCheckHostsFileContent(path, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		b, err := os.ReadFile(path)
+
+		if err != nil {
+			return err
+		}
+
+		if string(b) != expected {
+			return fmt.Errorf("unexpected content of %s:\n%s", path, b)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/sockets"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ListeningSocketsDataSource{}
+
+func NewListeningSocketsDataSource() datasource.DataSource {
+	return &ListeningSocketsDataSource{}
+}
+
+// ListeningSocketsDataSource defines the data source implementation.
+type ListeningSocketsDataSource struct {
+}
+
+// ListeningSocketsDataSourceModel describes the data source data model.
+type ListeningSocketsDataSourceModel struct {
+	Id       types.String `tfsdk:"id"`
+	Protocol types.String `tfsdk:"protocol"`
+	Port     types.Int64  `tfsdk:"port"`
+	Sockets  types.List   `tfsdk:"sockets"` //< SocketModel
+}
+
+type SocketModel struct {
+	Protocol types.String `tfsdk:"protocol"`
+	Family   types.String `tfsdk:"family"`
+	Address  types.String `tfsdk:"address"`
+	Port     types.Int64  `tfsdk:"port"`
+	PID      types.Int64  `tfsdk:"pid"`
+	Process  types.String `tfsdk:"process"`
+}
+
+func (d *ListeningSocketsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_listening_sockets"
+}
+
+func (d *ListeningSocketsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "`listening_sockets` data source gets the TCP and UDP sockets that are listening on the machine that reads the data source. " +
+			"Sockets are read from `/proc/net`, therefore this data source is only supported on Linux.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier",
+				Computed:            true,
+			},
+			"protocol": schema.StringAttribute{
+				MarkdownDescription: "If set, only return sockets for this protocol (`tcp` or `udp`).",
+				Optional:            true,
+			},
+			"port": schema.Int64Attribute{
+				MarkdownDescription: "If set, only return sockets bound to this port.",
+				Optional:            true,
+			},
+			"sockets": schema.ListAttribute{
+				MarkdownDescription: "Listening sockets",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: socketAttributeTypes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *ListeningSocketsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Nothing to configure
+}
+
+func socketAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"protocol": types.StringType,
+		"family":   types.StringType,
+		"address":  types.StringType,
+		"port":     types.Int64Type,
+		"pid":      types.Int64Type,
+		"process":  types.StringType,
+	}
+}
+
+func (d *ListeningSocketsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ListeningSocketsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	protocol := data.Protocol.ValueString()
+
+	if protocol != "" && protocol != "tcp" && protocol != "udp" {
+		resp.Diagnostics.AddError("Invalid protocol", fmt.Sprintf("Protocol must be \"tcp\" or \"udp\", got %q", protocol))
+		return
+	}
+
+	socketModels := make([]SocketModel, 0, 16)
+
+	if runtime.GOOS != "linux" {
+		resp.Diagnostics.AddWarning("Listening sockets are not available", fmt.Sprintf("Reading listening sockets is not supported on %s", runtime.GOOS))
+	} else {
+		socks, err := sockets.Scan(sockets.DefaultProcRoot)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to read listening sockets", err.Error())
+			return
+		}
+
+		for _, s := range socks {
+			if protocol != "" && s.Protocol != protocol {
+				continue
+			}
+
+			if !data.Port.IsNull() && int64(s.Port) != data.Port.ValueInt64() {
+				continue
+			}
+
+			socketModels = append(socketModels, socketToSocketModel(s))
+		}
+	}
+
+	resp.Diagnostics.Append(tfsdk.ValueFrom(ctx, socketModels, types.ListType{
+		ElemType: types.ObjectType{
+			AttrTypes: socketAttributeTypes(),
+		},
+	}, &data.Sockets)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(sockets.DefaultProcRoot + "/net")
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Read listening_sockets data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func socketToSocketModel(s *sockets.Socket) SocketModel {
+	m := SocketModel{
+		Protocol: types.StringValue(s.Protocol),
+		Family:   types.StringValue(s.Family),
+		Address:  types.StringValue(s.Address),
+		Port:     types.Int64Value(int64(s.Port)),
+		PID:      types.Int64Null(),
+		Process:  types.StringNull(),
+	}
+
+	// Owner is only known if we have permission to read the process's descriptors
+	if s.PID != 0 {
+		m.PID = types.Int64Value(int64(s.PID))
+		m.Process = types.StringValue(s.Process)
+	}
+
+	return m
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccListeningSocketsDataSource(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skipf("Listening sockets are not supported on %s", runtime.GOOS)
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer l.Close()
+
+	addr, ok := l.Addr().(*net.TCPAddr)
+
+	if !ok {
+		t.Fatalf("unexpected address type %T", l.Addr())
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: fmt.Sprintf(`
+data "localos_listening_sockets" "test" {
+  protocol = "tcp"
+  port     = %d
+}`, addr.Port),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.localos_listening_sockets.test", "sockets.#", "1"),
+					resource.TestCheckResourceAttr("data.localos_listening_sockets.test", "sockets.0.address", "127.0.0.1"),
+					resource.TestCheckResourceAttr("data.localos_listening_sockets.test", "sockets.0.family", "ipv4"),
+					resource.TestCheckResourceAttr("data.localos_listening_sockets.test", "sockets.0.pid", strconv.Itoa(os.Getpid())),
+				),
+			},
+		},
+	})
+}
+
+func TestAccListeningSocketsDataSourceInvalidProtocol(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      `data "localos_listening_sockets" "test" { protocol = "sctp" }`,
+				ExpectError: regexp.MustCompile(`Invalid protocol`),
+			},
+		},
+	})
+}
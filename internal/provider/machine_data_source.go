@@ -0,0 +1,150 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/machineid"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &MachineDataSource{}
+
+func NewMachineDataSource() datasource.DataSource {
+	return &MachineDataSource{}
+}
+
+// MachineDataSource defines the data source implementation.
+type MachineDataSource struct {
+}
+
+// MachineDataSourceModel describes the data source data model.
+type MachineDataSourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	ApplicationId types.String `tfsdk:"application_id"`
+	MachineId     types.String `tfsdk:"machine_id"`
+	BootId        types.String `tfsdk:"boot_id"`
+	Source        types.String `tfsdk:"source"`
+	SourcePath    types.String `tfsdk:"source_path"`
+}
+
+func (d *MachineDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_machine"
+}
+
+func (d *MachineDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "The `machine` data source gets a stable identity for the machine that is running terraform, " +
+			"from `/etc/machine-id` or `/var/lib/dbus/machine-id` on Linux, `MachineGuid` in the registry on Windows, or `IOPlatformUUID` on macOS. " +
+			"Where there is none of these, a random ID is generated and kept in the state directory of the user. " +
+			"As systemd recommends, the machine ID itself is not returned, but an ID derived from it for an application, " +
+			"so that IDs used by different applications cannot be correlated, and the machine ID cannot be recovered from them.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier, the same as `machine_id`",
+				Computed:            true,
+			},
+			"application_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the application the IDs are derived for. If this is a 128-bit ID, e.g. from `systemd-id128 new`, " +
+					"`machine_id` is the same as `systemd-id128 machine-id --app-specific=<application_id>` gives. " +
+					"Any other string, e.g. the name of the application, is first hashed to make an ID. " +
+					"Default the ID of this provider, which gives the same `machine_id` as the `id` of `localos_folders`.",
+				Optional: true,
+			},
+			"machine_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the machine for the application, as 32 hex digits.",
+				Computed:            true,
+			},
+			"boot_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the current boot of the machine for the application, which changes whenever the machine is restarted. " +
+					"Null on systems other than Linux.",
+				Computed: true,
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "Where the machine ID was read from: `systemd` for `/etc/machine-id`, `dbus` for `/var/lib/dbus/machine-id`, " +
+					"`windows` for the registry, `macos` for IOKit, or `generated` for an ID generated by the provider.",
+				Computed: true,
+			},
+			"source_path": schema.StringAttribute{
+				MarkdownDescription: "Path of the file the machine ID was read from, `HKLM\\SOFTWARE\\Microsoft\\Cryptography\\MachineGuid` on Windows, " +
+					"or `IOPlatformExpertDevice/IOPlatformUUID` on macOS. A generated ID is kept in " +
+					"`terraform-provider-localos/machine-id` under `$XDG_STATE_HOME` or `~/.local/state`, " +
+					"`~/Library/Application Support` on macOS, or `%LOCALAPPDATA%` on Windows, so each user has a different ID.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *MachineDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Nothing to configure
+}
+
+func (d *MachineDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data MachineDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appID := machineid.ProviderAppID
+
+	if !data.ApplicationId.IsNull() {
+		appID = data.ApplicationId.ValueString()
+	}
+
+	if appID == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("application_id"), "Invalid application ID", "Application ID must not be empty")
+		return
+	}
+
+	identity, err := machineid.Read(machineid.DefaultPaths())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read machine ID", err.Error())
+		return
+	}
+
+	machineID, err := machineid.AppSpecific(identity.MachineID, appID)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to derive machine ID", err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(machineID)
+	data.MachineId = types.StringValue(machineID)
+	data.BootId = types.StringNull()
+	data.Source = types.StringValue(identity.Source)
+	data.SourcePath = types.StringValue(identity.Path)
+
+	if identity.BootID != "" {
+		bootID, err := machineid.AppSpecific(identity.BootID, appID)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to derive boot ID", err.Error())
+			return
+		}
+
+		data.BootId = types.StringValue(bootID)
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Read machine data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
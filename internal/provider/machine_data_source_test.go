@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccMachineDataSource(t *testing.T) {
+	idRegex := regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: `
+data "localos_machine" "test" {}
+
+data "localos_machine" "app" {
+  application_id = "inventory"
+}
+
+data "localos_folders" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.localos_machine.test", "machine_id", idRegex),
+					resource.TestCheckResourceAttrPair("data.localos_machine.test", "id", "data.localos_machine.test", "machine_id"),
+					resource.TestCheckResourceAttrSet("data.localos_machine.test", "source"),
+					resource.TestCheckResourceAttrSet("data.localos_machine.test", "source_path"),
+					resource.TestMatchResourceAttr("data.localos_machine.app", "machine_id", idRegex),
+					resource.TestCheckResourceAttrPair("data.localos_folders.test", "id", "data.localos_machine.test", "machine_id"),
+				),
+			},
+		},
+	})
+}
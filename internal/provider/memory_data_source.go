@@ -0,0 +1,215 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/meminfo"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &MemoryDataSource{}
+
+func NewMemoryDataSource() datasource.DataSource {
+	return &MemoryDataSource{}
+}
+
+// MemoryDataSource defines the data source implementation.
+type MemoryDataSource struct {
+}
+
+// MemoryDataSourceModel describes the data source data model.
+type MemoryDataSourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	TotalBytes        types.Int64  `tfsdk:"total_bytes"`
+	AvailableBytes    types.Int64  `tfsdk:"available_bytes"`
+	FreeBytes         types.Int64  `tfsdk:"free_bytes"`
+	UsedBytes         types.Int64  `tfsdk:"used_bytes"`
+	BuffersBytes      types.Int64  `tfsdk:"buffers_bytes"`
+	CachedBytes       types.Int64  `tfsdk:"cached_bytes"`
+	SwapTotalBytes    types.Int64  `tfsdk:"swap_total_bytes"`
+	SwapFreeBytes     types.Int64  `tfsdk:"swap_free_bytes"`
+	SwapUsedBytes     types.Int64  `tfsdk:"swap_used_bytes"`
+	HugepagesTotal    types.Int64  `tfsdk:"hugepages_total"`
+	HugepagesFree     types.Int64  `tfsdk:"hugepages_free"`
+	HugepagesReserved types.Int64  `tfsdk:"hugepages_reserved"`
+	HugepagesSurplus  types.Int64  `tfsdk:"hugepages_surplus"`
+	HugepageSizeBytes types.Int64  `tfsdk:"hugepage_size_bytes"`
+	HugetlbBytes      types.Int64  `tfsdk:"hugetlb_bytes"`
+	Meminfo           types.Map    `tfsdk:"meminfo"`
+}
+
+func (d *MemoryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_memory"
+}
+
+func (d *MemoryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "The `memory` data source gets the memory and swap of the machine that is running terraform, from `/proc/meminfo`. " +
+			"All sizes are in bytes. On macOS only `total_bytes` is available, and on other systems none.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier",
+				Computed:            true,
+			},
+			"total_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Physical memory usable by the kernel.",
+				Computed:            true,
+			},
+			"available_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Estimate of the memory that can be given to new applications without swapping, including reclaimable caches. " +
+					"This is the best measure of how much memory can be given to e.g. a local VM.",
+				Computed: true,
+			},
+			"free_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Memory that is not used at all. Usually much less than `available_bytes`, as Linux uses spare memory for caches.",
+				Computed:            true,
+			},
+			"used_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Memory used by processes and the kernel, calculated as by `free`: total less free, buffers and cache.",
+				Computed:            true,
+			},
+			"buffers_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Memory used by kernel buffers.",
+				Computed:            true,
+			},
+			"cached_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Memory used by the page cache and reclaimable slab, as the `cache` column of `free`.",
+				Computed:            true,
+			},
+			"swap_total_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Total swap space. 0 if there is no swap.",
+				Computed:            true,
+			},
+			"swap_free_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Unused swap space.",
+				Computed:            true,
+			},
+			"swap_used_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Swap space in use.",
+				Computed:            true,
+			},
+			"hugepages_total": schema.Int64Attribute{
+				MarkdownDescription: "Number of huge pages of the default size in the pool, as set by `vm.nr_hugepages`.",
+				Computed:            true,
+			},
+			"hugepages_free": schema.Int64Attribute{
+				MarkdownDescription: "Number of huge pages in the pool that are not yet allocated.",
+				Computed:            true,
+			},
+			"hugepages_reserved": schema.Int64Attribute{
+				MarkdownDescription: "Number of huge pages that have been reserved but not yet allocated.",
+				Computed:            true,
+			},
+			"hugepages_surplus": schema.Int64Attribute{
+				MarkdownDescription: "Number of huge pages allocated above `hugepages_total`, up to `vm.nr_overcommit_hugepages`.",
+				Computed:            true,
+			},
+			"hugepage_size_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Default size of a huge page, e.g. 2097152 on x86.",
+				Computed:            true,
+			},
+			"hugetlb_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Memory used by huge pages of all sizes.",
+				Computed:            true,
+			},
+			"meminfo": schema.MapAttribute{
+				MarkdownDescription: "All fields of `/proc/meminfo`, e.g. `Committed_AS`, with key=field name, value=size in bytes. " +
+					"The `HugePages_` fields are counts of pages.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *MemoryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Nothing to configure
+}
+
+func (d *MemoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data MemoryDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue("memory")
+	data.setNull()
+
+	if runtime.GOOS == "linux" {
+		if info, err := meminfo.Read(meminfo.DefaultProcRoot); err != nil {
+			resp.Diagnostics.AddWarning("Unable to read memory details", err.Error())
+		} else {
+			resp.Diagnostics.Append(data.set(ctx, info)...)
+		}
+	} else if total, err := meminfo.Total(); err == nil {
+		data.TotalBytes = types.Int64Value(int64(total))
+	} else {
+		resp.Diagnostics.AddWarning("Memory details are not available", fmt.Sprintf("Reading memory details is not supported on %s", runtime.GOOS))
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Read memory data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// setNull sets all the measurements to null, for when they cannot be read.
+func (m *MemoryDataSourceModel) setNull() {
+	for _, v := range []*types.Int64{
+		&m.TotalBytes, &m.AvailableBytes, &m.FreeBytes, &m.UsedBytes, &m.BuffersBytes, &m.CachedBytes,
+		&m.SwapTotalBytes, &m.SwapFreeBytes, &m.SwapUsedBytes,
+		&m.HugepagesTotal, &m.HugepagesFree, &m.HugepagesReserved, &m.HugepagesSurplus, &m.HugepageSizeBytes, &m.HugetlbBytes,
+	} {
+		*v = types.Int64Null()
+	}
+
+	m.Meminfo = types.MapNull(types.Int64Type)
+}
+
+// set copies the measurements from info.
+func (m *MemoryDataSourceModel) set(ctx context.Context, info *meminfo.Info) diag.Diagnostics {
+	m.TotalBytes = types.Int64Value(int64(info.Total))
+	m.AvailableBytes = types.Int64Value(int64(info.Available))
+	m.FreeBytes = types.Int64Value(int64(info.Free))
+	m.UsedBytes = types.Int64Value(int64(info.Used()))
+	m.BuffersBytes = types.Int64Value(int64(info.Buffers))
+	m.CachedBytes = types.Int64Value(int64(info.Cached))
+	m.SwapTotalBytes = types.Int64Value(int64(info.SwapTotal))
+	m.SwapFreeBytes = types.Int64Value(int64(info.SwapFree))
+	m.SwapUsedBytes = types.Int64Value(int64(info.SwapUsed()))
+	m.HugepagesTotal = types.Int64Value(int64(info.HugePagesTotal))
+	m.HugepagesFree = types.Int64Value(int64(info.HugePagesFree))
+	m.HugepagesReserved = types.Int64Value(int64(info.HugePagesReserved))
+	m.HugepagesSurplus = types.Int64Value(int64(info.HugePagesSurplus))
+	m.HugepageSizeBytes = types.Int64Value(int64(info.HugePageSize))
+	m.HugetlbBytes = types.Int64Value(int64(info.Hugetlb))
+
+	fields := make(map[string]int64, len(info.Fields))
+
+	for k, v := range info.Fields {
+		fields[k] = int64(v)
+	}
+
+	var diags diag.Diagnostics
+	m.Meminfo, diags = types.MapValueFrom(ctx, types.Int64Type, fields)
+
+	return diags
+}
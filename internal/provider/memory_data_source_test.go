@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccMemoryDataSource(t *testing.T) {
+	checks := []resource.TestCheckFunc{
+		resource.TestCheckResourceAttr("data.localos_memory.test", "id", "memory"),
+	}
+
+	if runtime.GOOS == "linux" {
+		checks = append(checks,
+			resource.TestCheckResourceAttrSet("data.localos_memory.test", "total_bytes"),
+			resource.TestCheckResourceAttrSet("data.localos_memory.test", "available_bytes"),
+			resource.TestCheckResourceAttrSet("data.localos_memory.test", "swap_total_bytes"),
+			resource.TestCheckResourceAttrSet("data.localos_memory.test", "hugepage_size_bytes"),
+			resource.TestCheckResourceAttrSet("data.localos_memory.test", "meminfo.MemTotal"),
+		)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: `data "localos_memory" "test" {}`,
+				Check:  resource.ComposeAggregateTestCheckFunc(checks...),
+			},
+		},
+	})
+}
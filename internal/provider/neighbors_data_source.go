@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/neighbors"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &NeighborsDataSource{}
+
+func NewNeighborsDataSource() datasource.DataSource {
+	return &NeighborsDataSource{}
+}
+
+// NeighborsDataSource defines the data source implementation.
+type NeighborsDataSource struct {
+}
+
+// NeighborsDataSourceModel describes the data source data model.
+type NeighborsDataSourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	Family    types.String `tfsdk:"family"`
+	Interface types.String `tfsdk:"interface"`
+	Neighbors types.List   `tfsdk:"neighbors"` //< NeighborModel
+}
+
+type NeighborModel struct {
+	Address   types.String `tfsdk:"address"`
+	Family    types.String `tfsdk:"family"`
+	Interface types.String `tfsdk:"interface"`
+	MAC       types.String `tfsdk:"mac"`
+	State     types.String `tfsdk:"state"`
+}
+
+func (d *NeighborsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_neighbors"
+}
+
+func (d *NeighborsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "`neighbors` data source gets the kernel's neighbour tables (ARP for IPv4, NDP for IPv6) on the machine that reads the data source, " +
+			"i.e. the devices on the local network segments it has recently communicated with. This data source is only supported on Linux.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier",
+				Computed:            true,
+			},
+			"family": schema.StringAttribute{
+				MarkdownDescription: "If set, only return neighbours of this address family (`ipv4` or `ipv6`). If not set and the IPv6 table cannot be read, the IPv4 neighbours are returned with a warning.",
+				Optional:            true,
+			},
+			"interface": schema.StringAttribute{
+				MarkdownDescription: "If set, only return neighbours on this network interface, e.g. `eth0`.",
+				Optional:            true,
+			},
+			"neighbors": schema.ListAttribute{
+				MarkdownDescription: "Neighbours, sorted by interface then address",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: neighborAttributeTypes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *NeighborsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Nothing to configure
+}
+
+func neighborAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"address":   types.StringType,
+		"family":    types.StringType,
+		"interface": types.StringType,
+		"mac":       types.StringType,
+		"state":     types.StringType,
+	}
+}
+
+func (d *NeighborsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NeighborsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	family := data.Family.ValueString()
+
+	if family != "" && family != "ipv4" && family != "ipv6" {
+		resp.Diagnostics.AddError("Invalid family", fmt.Sprintf("Family must be \"ipv4\" or \"ipv6\", got %q", family))
+		return
+	}
+
+	neighborModels := make([]NeighborModel, 0, 16)
+
+	if runtime.GOOS != "linux" {
+		resp.Diagnostics.AddWarning("Neighbour tables are not available", fmt.Sprintf("Reading neighbour tables is not supported on %s", runtime.GOOS))
+	} else {
+		list, err := neighbors.List(neighbors.DefaultProcRoot, family)
+
+		if errors.Is(err, neighbors.ErrNDP) {
+			resp.Diagnostics.AddWarning("IPv6 neighbours are not available", err.Error())
+		} else if err != nil {
+			resp.Diagnostics.AddError("Unable to read neighbour tables", err.Error())
+			return
+		}
+
+		for _, n := range list {
+			if !data.Interface.IsNull() && n.Interface != data.Interface.ValueString() {
+				continue
+			}
+
+			neighborModels = append(neighborModels, neighborToNeighborModel(n))
+		}
+	}
+
+	resp.Diagnostics.Append(tfsdk.ValueFrom(ctx, neighborModels, types.ListType{
+		ElemType: types.ObjectType{
+			AttrTypes: neighborAttributeTypes(),
+		},
+	}, &data.Neighbors)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(neighbors.DefaultProcRoot + "/net/arp")
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Read neighbors data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func neighborToNeighborModel(n *neighbors.Neighbor) NeighborModel {
+	m := NeighborModel{
+		Address:   types.StringValue(n.Address),
+		Family:    types.StringValue(n.Family),
+		Interface: types.StringValue(n.Interface),
+		MAC:       types.StringNull(),
+		State:     types.StringValue(n.State),
+	}
+
+	// Link layer address is unknown until resolution completes
+	if n.MAC != "" {
+		m.MAC = types.StringValue(n.MAC)
+	}
+
+	return m
+}
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccNeighborsDataSource(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skipf("Neighbour tables are not supported on %s", runtime.GOOS)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: `data "localos_neighbors" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.localos_neighbors.test", "id", "/proc/net/arp"),
+					resource.TestCheckResourceAttrSet("data.localos_neighbors.test", "neighbors.#"),
+				),
+			},
+			// Content of the tables depends on the network, so only check that filters are honoured
+			{
+				Config: `
+data "localos_neighbors" "test" {
+  family    = "ipv4"
+  interface = "no-such-if0"
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.localos_neighbors.test", "neighbors.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNeighborsDataSourceInvalidFamily(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      `data "localos_neighbors" "test" { family = "ipx" }`,
+				ExpectError: regexp.MustCompile(`Invalid family`),
+			},
+		},
+	})
+}
@@ -5,13 +5,20 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/envvars"
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/osrelease"
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/uname"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -33,7 +40,23 @@ type OsInfoDataSourceModel struct {
 	Name        types.String `tfsdk:"name"`
 	Arch        types.String `tfsdk:"arch"`
 	Windows     types.Bool   `tfsdk:"is_windows"`
+	Include     types.List   `tfsdk:"include"`
+	Exclude     types.List   `tfsdk:"exclude"`
+	Public      types.List   `tfsdk:"public"`
 	Environment types.Map    `tfsdk:"environment"`
+	EnvPublic   types.Map    `tfsdk:"environment_public"`
+	Path        types.List   `tfsdk:"path"`
+
+	DistributionId         types.String `tfsdk:"distribution_id"`
+	DistributionIdLike     types.List   `tfsdk:"distribution_id_like"`
+	DistributionVersion    types.String `tfsdk:"distribution_version"`
+	DistributionCodename   types.String `tfsdk:"distribution_codename"`
+	DistributionPrettyName types.String `tfsdk:"distribution_pretty_name"`
+	OsRelease              types.Map    `tfsdk:"os_release"`
+	KernelRelease          types.String `tfsdk:"kernel_release"`
+	KernelVersion          types.String `tfsdk:"kernel_version"`
+	Machine                types.String `tfsdk:"machine"`
+	Nodename               types.String `tfsdk:"nodename"`
 }
 
 func (d *OsInfoDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -62,12 +85,81 @@ func (d *OsInfoDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				MarkdownDescription: "Utility attribute to quickly determine windows/not windows. Other supported OS are assumed to follow POSIX semantics.",
 				Computed:            true,
 			},
+			"include": schema.ListAttribute{
+				MarkdownDescription: "If set, `environment` only contains variables whose names match one of these patterns. " +
+					"A pattern is a prefix of the name, e.g. `AWS_`, or a regular expression between slashes, e.g. `/^TF_VAR_/`.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"exclude": schema.ListAttribute{
+				MarkdownDescription: "Variables whose names match one of these patterns are left out of `environment`. Patterns are as for `include`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"public": schema.ListAttribute{
+				MarkdownDescription: "Names of variables that are not secret, and are copied to `environment_public`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
 			"environment": schema.MapAttribute{
-				MarkdownDescription: "Map of all environment variables, with key=variable name (case sensitive), value=variable value.",
+				MarkdownDescription: "Map of environment variables selected by `include` and `exclude`, with key=variable name (case sensitive), value=variable value.",
 				ElementType:         types.StringType,
 				Computed:            true,
 				Sensitive:           true,
 			},
+			"environment_public": schema.MapAttribute{
+				MarkdownDescription: "Map of the variables in `environment` that are named in `public`. Not sensitive, so values can be shown in plan output.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"path": schema.ListAttribute{
+				MarkdownDescription: "Directories in the `PATH` variable, in order, split with the separator of the OS (`:`, or `;` on windows).",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"distribution_id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the distribution, `ID` from os-release, e.g. `ubuntu`, `alpine`. " +
+					"`linux` on Linux if os-release does not exist. Null on systems other than Linux and BSD.",
+				Computed: true,
+			},
+			"distribution_id_like": schema.ListAttribute{
+				MarkdownDescription: "Identifiers of the distributions this one is derived from, closest first, `ID_LIKE` from os-release, e.g. `[\"rhel\", \"fedora\"]`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"distribution_version": schema.StringAttribute{
+				MarkdownDescription: "Version of the distribution, `VERSION_ID` from os-release, e.g. `22.04`. Null if not set, e.g. on rolling releases.",
+				Computed:            true,
+			},
+			"distribution_codename": schema.StringAttribute{
+				MarkdownDescription: "Code name of the release, `VERSION_CODENAME` from os-release, e.g. `jammy`. Null if not set.",
+				Computed:            true,
+			},
+			"distribution_pretty_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the distribution to show to users, `PRETTY_NAME` from os-release, e.g. `Ubuntu 22.04.3 LTS`.",
+				Computed:            true,
+			},
+			"os_release": schema.MapAttribute{
+				MarkdownDescription: "All fields of `/etc/os-release`, or `/usr/lib/os-release` if that does not exist. Empty if neither exists.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"kernel_release": schema.StringAttribute{
+				MarkdownDescription: "Kernel release from `uname -r`, e.g. `6.5.0-14-generic`. On Windows, the version of Windows, e.g. `10.0.22631`.",
+				Computed:            true,
+			},
+			"kernel_version": schema.StringAttribute{
+				MarkdownDescription: "Kernel version from `uname -v`, e.g. `#14~22.04.1-Ubuntu SMP PREEMPT_DYNAMIC`. On Windows, the service pack, if any.",
+				Computed:            true,
+			},
+			"machine": schema.StringAttribute{
+				MarkdownDescription: "Hardware name from `uname -m`, e.g. `x86_64`, `aarch64`, `arm64`. On Windows, e.g. `AMD64`.",
+				Computed:            true,
+			},
+			"nodename": schema.StringAttribute{
+				MarkdownDescription: "Node name from `uname -n`. On Windows this is the host name.",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -93,15 +185,25 @@ func (d *OsInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	data.Arch = types.StringValue(arch)
 	data.Windows = types.BoolValue(goos == "windows")
 
-	env := make(map[string]attr.Value)
-	for _, envvar := range os.Environ() {
-		s := strings.Split(envvar, "=")
-		env[s[0]] = types.StringValue(s[1])
+	include, exclude, public := d.patterns(ctx, &data, resp)
+
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	m, diags := types.MapValue(types.StringType, env)
-	data.Environment = m
+	env := envvars.Filter(envvars.Parse(os.Environ()), include, exclude)
+
+	var diags diag.Diagnostics
+	data.Environment, diags = types.MapValueFrom(ctx, types.StringType, env)
+	resp.Diagnostics.Append(diags...)
+	data.EnvPublic, diags = types.MapValueFrom(ctx, types.StringType, envvars.Select(env, public))
 	resp.Diagnostics.Append(diags...)
+	data.Path, diags = types.ListValueFrom(ctx, types.StringType, filepath.SplitList(os.Getenv("PATH")))
+	resp.Diagnostics.Append(diags...)
+
+	d.readRelease(ctx, &data, resp)
+	d.readUname(&data, resp)
+
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
 	tflog.Trace(ctx, "Read osinfo data source")
@@ -109,3 +211,83 @@ func (d *OsInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// patterns gets the include and exclude patterns and public names from the configuration.
+func (d *OsInfoDataSource) patterns(ctx context.Context, data *OsInfoDataSourceModel, resp *datasource.ReadResponse) (include, exclude []*envvars.Pattern, public []string) {
+	get := func(name string, list types.List) []*envvars.Pattern {
+		var s []string
+		resp.Diagnostics.Append(list.ElementsAs(ctx, &s, false)...)
+
+		patterns, err := envvars.ParsePatterns(s)
+
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(name), "Invalid pattern", err.Error())
+		}
+
+		return patterns
+	}
+
+	include = get("include", data.Include)
+	exclude = get("exclude", data.Exclude)
+	resp.Diagnostics.Append(data.Public.ElementsAs(ctx, &public, false)...)
+
+	return include, exclude, public
+}
+
+// readRelease sets the distribution attributes from os-release.
+func (d *OsInfoDataSource) readRelease(ctx context.Context, data *OsInfoDataSourceModel, resp *datasource.ReadResponse) {
+	data.DistributionId = types.StringNull()
+	data.DistributionIdLike = types.ListNull(types.StringType)
+	data.DistributionVersion = types.StringNull()
+	data.DistributionCodename = types.StringNull()
+	data.DistributionPrettyName = types.StringNull()
+	data.OsRelease = types.MapValueMust(types.StringType, map[string]attr.Value{})
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	release, err := osrelease.Read(osrelease.DefaultPaths...)
+
+	if errors.Is(err, os.ErrNotExist) {
+		if runtime.GOOS != "linux" {
+			return
+		}
+
+		// os-release(5) defines the defaults to use if the file does not exist
+		release, err = osrelease.Parse(strings.NewReader(""))
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to read os-release", err.Error())
+		return
+	}
+
+	var diags diag.Diagnostics
+	data.DistributionId = types.StringValue(release.ID())
+	data.DistributionIdLike, diags = types.ListValueFrom(ctx, types.StringType, release.IDLike())
+	resp.Diagnostics.Append(diags...)
+	data.DistributionVersion = optionalString(release.Get("VERSION_ID"))
+	data.DistributionCodename = optionalString(release.Get("VERSION_CODENAME"))
+	data.DistributionPrettyName = types.StringValue(release.PrettyName())
+	data.OsRelease, diags = types.MapValueFrom(ctx, types.StringType, release.Fields)
+	resp.Diagnostics.Append(diags...)
+}
+
+// readUname sets the kernel attributes from uname.
+func (d *OsInfoDataSource) readUname(data *OsInfoDataSourceModel, resp *datasource.ReadResponse) {
+	u, err := uname.Uname()
+
+	if err != nil {
+		if !errors.Is(err, uname.ErrNotSupported) {
+			resp.Diagnostics.AddWarning("Unable to get kernel details", err.Error())
+		}
+
+		u = &uname.Utsname{}
+	}
+
+	data.KernelRelease = optionalString(u.Release)
+	data.KernelVersion = optionalString(u.Version)
+	data.Machine = optionalString(u.Machine)
+	data.Nodename = optionalString(u.Nodename)
+}
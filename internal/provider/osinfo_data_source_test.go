@@ -6,6 +6,8 @@ package provider
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
@@ -24,11 +26,20 @@ func TestAccOsInfoDataSource(t *testing.T) {
 		resource.TestCheckResourceAttr("data.localos_info.test", "name", expectedName),
 		resource.TestCheckResourceAttr("data.localos_info.test", "arch", expectedArch),
 		resource.TestCheckResourceAttr("data.localos_info.test", "is_windows", strconv.FormatBool(is_windows)),
+		resource.TestCheckResourceAttrSet("data.localos_info.test", "kernel_release"),
+		resource.TestCheckResourceAttrSet("data.localos_info.test", "machine"),
+	}
+
+	if expectedName == "linux" {
+		checks = append(checks, resource.TestCheckResourceAttrSet("data.localos_info.test", "distribution_id"))
 	}
 
 	for _, e := range os.Environ() {
-		kv := strings.Split(e, "=")
-		checks = append(checks, resource.TestCheckResourceAttr("data.localos_info.test", fmt.Sprintf("environment.%s", kv[0]), kv[1]))
+		k, v, _ := strings.Cut(e, "=")
+		if k == "" {
+			continue
+		}
+		checks = append(checks, resource.TestCheckResourceAttr("data.localos_info.test", fmt.Sprintf("environment.%s", k), v))
 	}
 
 	resource.Test(t, resource.TestCase{
@@ -42,3 +53,44 @@ func TestAccOsInfoDataSource(t *testing.T) {
 		},
 	})
 }
+
+func TestAccOsInfoDataSourceFiltered(t *testing.T) {
+	t.Setenv("LOCALOS_TEST_TOKEN", "dGVzdA==")
+	t.Setenv("LOCALOS_TEST_REGION", "eu-west-1")
+	t.Setenv("LOCALOS_TEST_SECRET", "secret")
+
+	// PATH is left as it is, as terraform is found through it
+	path := filepath.SplitList(os.Getenv("PATH"))
+	checks := []resource.TestCheckFunc{
+		resource.TestCheckResourceAttr("data.localos_info.test", "environment.%", "3"),
+		resource.TestCheckResourceAttr("data.localos_info.test", "environment.LOCALOS_TEST_TOKEN", "dGVzdA=="),
+		resource.TestCheckNoResourceAttr("data.localos_info.test", "environment.LOCALOS_TEST_SECRET"),
+		resource.TestCheckResourceAttr("data.localos_info.test", "environment_public.%", "1"),
+		resource.TestCheckResourceAttr("data.localos_info.test", "environment_public.LOCALOS_TEST_REGION", "eu-west-1"),
+		resource.TestCheckResourceAttr("data.localos_info.test", "path.#", strconv.Itoa(len(path))),
+	}
+
+	for i, dir := range path {
+		checks = append(checks, resource.TestCheckResourceAttr("data.localos_info.test", fmt.Sprintf("path.%d", i), dir))
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: `
+data "localos_info" "test" {
+  include = ["LOCALOS_TEST_", "/^PATH$/"]
+  exclude = ["/SECRET/"]
+  public  = ["LOCALOS_TEST_REGION", "LOCALOS_TEST_SECRET"]
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(checks...),
+			},
+			{
+				Config:      `data "localos_info" "test" { include = ["/[/"] }`,
+				ExpectError: regexp.MustCompile(`Invalid pattern`),
+			},
+		},
+	})
+}
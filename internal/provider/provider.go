@@ -5,6 +5,7 @@ package provider
 
 import (
 	"context"
+	"net"
 	"net/http"
 
 	"github.com/fireflycons/terraform-provider-localos/internal/helpers/privateip"
@@ -44,6 +45,8 @@ on which you are running terraform.
 
 In certain situations it can be useful to know if your configuration is running on Windows or not,
 especically for storing locally created artifacts such as key pairs in the appropriate directories.
+
+It also has a small number of resources for managing local configuration, such as hosts file entries.
 `,
 	}
 }
@@ -59,6 +62,7 @@ func (p *LocalOsProvider) Configure(ctx context.Context, req provider.ConfigureR
 
 	client := ConfigurationData{
 		httpClient:      http.DefaultClient,
+		dialer:          &net.Dialer{},
 		localInterfaces: p.localInterfaces,
 	}
 	resp.DataSourceData = client
@@ -66,7 +70,10 @@ func (p *LocalOsProvider) Configure(ctx context.Context, req provider.ConfigureR
 }
 
 func (p *LocalOsProvider) Resources(ctx context.Context) []func() resource.Resource {
-	return nil
+	return []func() resource.Resource{
+		NewHostsEntryResource,
+		NewEnvFileResource,
+	}
 }
 
 func (p *LocalOsProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
@@ -75,6 +82,27 @@ func (p *LocalOsProvider) DataSources(ctx context.Context) []func() datasource.D
 		NewFoldersDataSource,
 		NewPublicIPDataSource,
 		NewPrivateIPDataSource,
+		NewListeningSocketsDataSource,
+		NewTcpCheckDataSource,
+		NewDnsConfigDataSource,
+		NewDnsLookupDataSource,
+		NewHostnameDataSource,
+		NewHostsDataSource,
+		NewProxyDataSource,
+		NewNeighborsDataSource,
+		NewDhcpLeaseDataSource,
+		NewWireguardDataSource,
+		NewEnvDataSource,
+		NewDotenvDataSource,
+		NewCpuDataSource,
+		NewMemoryDataSource,
+		NewFilesystemDataSource,
+		NewCgroupDataSource,
+		NewRuntimeEnvironmentDataSource,
+		NewCiDataSource,
+		NewCloudInstanceDataSource,
+		NewHardwareDataSource,
+		NewMachineDataSource,
 	}
 }
 
@@ -0,0 +1,243 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"os"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/proxyconfig"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ProxyDataSource{}
+
+func NewProxyDataSource() datasource.DataSource {
+	return &ProxyDataSource{}
+}
+
+// ProxyDataSource defines the data source implementation.
+type ProxyDataSource struct {
+}
+
+// ProxyDataSourceModel describes the data source data model.
+type ProxyDataSourceModel struct {
+	Id                 types.String `tfsdk:"id"`
+	Files              types.List   `tfsdk:"files"`
+	Urls               types.List   `tfsdk:"urls"`
+	IncludeCredentials types.Bool   `tfsdk:"include_credentials"`
+	HttpProxy          types.String `tfsdk:"http_proxy"`
+	HttpsProxy         types.String `tfsdk:"https_proxy"`
+	NoProxy            types.String `tfsdk:"no_proxy"`
+	NoProxyList        types.List   `tfsdk:"no_proxy_list"`
+	AllProxy           types.String `tfsdk:"all_proxy"`
+	Variables          types.Map    `tfsdk:"variables"`
+	Credentials        types.Map    `tfsdk:"proxies_with_credentials"`
+	Evaluations        types.List   `tfsdk:"evaluations"` //< ProxyEvaluationModel
+}
+
+type ProxyEvaluationModel struct {
+	Url    types.String `tfsdk:"url"`
+	Proxy  types.String `tfsdk:"proxy"`
+	Direct types.Bool   `tfsdk:"direct"`
+}
+
+func (d *ProxyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_proxy"
+}
+
+func (d *ProxyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "`proxy` data source gets the HTTP proxy configuration of the environment that is running terraform, " +
+			"and optionally which proxy would be used for given URLs.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier",
+				Computed:            true,
+			},
+			"files": schema.ListAttribute{
+				MarkdownDescription: "Files of `KEY=value` lines, such as `/etc/environment`, to read proxy variables from when they are not set in the environment. " +
+					"Lines may begin with `export`. Where a variable is set in more than one file, the first file listed wins.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"urls": schema.ListAttribute{
+				MarkdownDescription: "URLs to evaluate. For each, `evaluations` reports the proxy that Go's `http.ProxyFromEnvironment` would select.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"include_credentials": schema.BoolAttribute{
+				MarkdownDescription: "Whether to return passwords found in proxy URLs in `proxies_with_credentials`. Default `false`. " +
+					"Passwords in all other attributes are always replaced by `xxxxx`.",
+				Optional: true,
+			},
+			"http_proxy": schema.StringAttribute{
+				MarkdownDescription: "Value of `HTTP_PROXY`, or `http_proxy` if that is not set. Empty if neither is set.",
+				Computed:            true,
+			},
+			"https_proxy": schema.StringAttribute{
+				MarkdownDescription: "Value of `HTTPS_PROXY`, or `https_proxy` if that is not set. Empty if neither is set.",
+				Computed:            true,
+			},
+			"no_proxy": schema.StringAttribute{
+				MarkdownDescription: "Value of `NO_PROXY`, or `no_proxy` if that is not set. Empty if neither is set.",
+				Computed:            true,
+			},
+			"no_proxy_list": schema.ListAttribute{
+				MarkdownDescription: "Entries of `no_proxy`",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"all_proxy": schema.StringAttribute{
+				MarkdownDescription: "Value of `ALL_PROXY`, or `all_proxy` if that is not set. This is used by curl and other tools, but not by Go programs such as terraform.",
+				Computed:            true,
+			},
+			"variables": schema.MapAttribute{
+				MarkdownDescription: "All proxy variables found, in either case, with their values",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"proxies_with_credentials": schema.MapAttribute{
+				MarkdownDescription: "Values of `http_proxy`, `https_proxy` and `all_proxy` with any passwords, keyed by those names. " +
+					"Null unless `include_credentials` is true. This is sensitive, so is not shown in plans.",
+				ElementType: types.StringType,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"evaluations": schema.ListAttribute{
+				MarkdownDescription: "Proxy selected for each of `urls`",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: proxyEvaluationAttributeTypes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *ProxyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Nothing to configure
+}
+
+func proxyEvaluationAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"url":    types.StringType,
+		"proxy":  types.StringType,
+		"direct": types.BoolType,
+	}
+}
+
+func (d *ProxyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ProxyDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var files, urls []string
+
+	resp.Diagnostics.Append(data.Files.ElementsAs(ctx, &files, false)...)
+	resp.Diagnostics.Append(data.Urls.ElementsAs(ctx, &urls, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Environment takes precedence over files
+	lookups := []proxyconfig.Lookup{os.LookupEnv}
+
+	for _, f := range files {
+		lookup, err := proxyconfig.ReadFile(f)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to read proxy configuration file", err.Error())
+			return
+		}
+
+		lookups = append(lookups, lookup)
+	}
+
+	settings := proxyconfig.New(lookups...)
+
+	// Passwords are only returned in the sensitive attribute, so that they are not shown in plans
+	data.HttpProxy = types.StringValue(proxyconfig.Redact(settings.HTTPProxy))
+	data.HttpsProxy = types.StringValue(proxyconfig.Redact(settings.HTTPSProxy))
+	data.NoProxy = types.StringValue(settings.NoProxy)
+	data.AllProxy = types.StringValue(proxyconfig.Redact(settings.AllProxy))
+	data.Credentials = types.MapNull(types.StringType)
+
+	variables := make(map[string]string, len(settings.Variables))
+
+	for k, v := range settings.Variables {
+		variables[k] = proxyconfig.Redact(v)
+	}
+
+	var diags diag.Diagnostics
+	data.NoProxyList, diags = types.ListValueFrom(ctx, types.StringType, settings.NoProxyList())
+	resp.Diagnostics.Append(diags...)
+	data.Variables, diags = types.MapValueFrom(ctx, types.StringType, variables)
+	resp.Diagnostics.Append(diags...)
+
+	if data.IncludeCredentials.ValueBool() {
+		data.Credentials, diags = types.MapValueFrom(ctx, types.StringType, map[string]string{
+			"http_proxy":  settings.HTTPProxy,
+			"https_proxy": settings.HTTPSProxy,
+			"all_proxy":   settings.AllProxy,
+		})
+		resp.Diagnostics.Append(diags...)
+	}
+
+	evaluations := make([]ProxyEvaluationModel, 0, len(urls))
+
+	for _, u := range urls {
+		proxy, err := settings.ProxyFor(u)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to evaluate proxy for URL", err.Error())
+			return
+		}
+
+		e := ProxyEvaluationModel{
+			Url:    types.StringValue(u),
+			Proxy:  types.StringNull(),
+			Direct: types.BoolValue(proxy == nil),
+		}
+
+		if proxy != nil {
+			e.Proxy = types.StringValue(proxyconfig.Redact(proxy.String()))
+		}
+
+		evaluations = append(evaluations, e)
+	}
+
+	resp.Diagnostics.Append(tfsdk.ValueFrom(ctx, evaluations, types.ListType{
+		ElemType: types.ObjectType{
+			AttrTypes: proxyEvaluationAttributeTypes(),
+		},
+	}, &data.Evaluations)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue("proxy")
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Read proxy data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/runtimeenv"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RuntimeEnvironmentDataSource{}
+
+func NewRuntimeEnvironmentDataSource() datasource.DataSource {
+	return &RuntimeEnvironmentDataSource{}
+}
+
+// RuntimeEnvironmentDataSource defines the data source implementation.
+type RuntimeEnvironmentDataSource struct {
+}
+
+// RuntimeEnvironmentDataSourceModel describes the data source data model.
+type RuntimeEnvironmentDataSourceModel struct {
+	Id                  types.String `tfsdk:"id"`
+	IsContainer         types.Bool   `tfsdk:"is_container"`
+	ContainerEngine     types.String `tfsdk:"container_engine"`
+	IsKubernetes        types.Bool   `tfsdk:"is_kubernetes"`
+	KubernetesNamespace types.String `tfsdk:"kubernetes_namespace"`
+	IsWsl               types.Bool   `tfsdk:"is_wsl"`
+	WslVersion          types.Int64  `tfsdk:"wsl_version"`
+	WslDistro           types.String `tfsdk:"wsl_distro"`
+	IsVirtualMachine    types.Bool   `tfsdk:"is_virtual_machine"`
+	Hypervisor          types.String `tfsdk:"hypervisor"`
+	Evidence            types.List   `tfsdk:"evidence"`
+}
+
+func (d *RuntimeEnvironmentDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_runtime_environment"
+}
+
+func (d *RuntimeEnvironmentDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "The `runtime_environment` data source detects whether terraform is running in a container, Kubernetes, " +
+			"the Windows Subsystem for Linux or a virtual machine. Detection is only supported on Linux; on other systems, all are false.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier",
+				Computed:            true,
+			},
+			"is_container": schema.BoolAttribute{
+				MarkdownDescription: "True if running in a container, from `/.dockerenv`, `/run/.containerenv`, the `container` environment variable, " +
+					"`/run/systemd/container`, the cgroup path, or Kubernetes.",
+				Computed: true,
+			},
+			"container_engine": schema.StringAttribute{
+				MarkdownDescription: "Container engine: `docker`, `podman`, `lxc`, `systemd-nspawn` or as set in the `container` environment variable. " +
+					"Null if not in a container, or the engine is not known, as is usual in Kubernetes.",
+				Computed: true,
+			},
+			"is_kubernetes": schema.BoolAttribute{
+				MarkdownDescription: "True if running in a Kubernetes pod, from the service account mount or `KUBERNETES_SERVICE_HOST`.",
+				Computed:            true,
+			},
+			"kubernetes_namespace": schema.StringAttribute{
+				MarkdownDescription: "Namespace of the pod, from the service account mount or `POD_NAMESPACE`. Null if not known.",
+				Computed:            true,
+			},
+			"is_wsl": schema.BoolAttribute{
+				MarkdownDescription: "True if running in the Windows Subsystem for Linux. `localos_info` then reports Linux, and `localos_folders` the Linux home directory.",
+				Computed:            true,
+			},
+			"wsl_version": schema.Int64Attribute{
+				MarkdownDescription: "`1` or `2`, from the kernel release. Null if not in WSL.",
+				Computed:            true,
+			},
+			"wsl_distro": schema.StringAttribute{
+				MarkdownDescription: "Name of the WSL distribution, from `WSL_DISTRO_NAME`. Null if not known.",
+				Computed:            true,
+			},
+			"is_virtual_machine": schema.BoolAttribute{
+				MarkdownDescription: "True if running in a virtual machine, from DMI, `/sys/hypervisor` or the `hypervisor` CPU flag. " +
+					"This is also true in a container on a virtual machine, and in WSL2.",
+				Computed: true,
+			},
+			"hypervisor": schema.StringAttribute{
+				MarkdownDescription: "Hypervisor, e.g. `kvm`, `qemu`, `vmware`, `hyperv`, `virtualbox`, `xen`, `amazon` or `google`, from DMI or `/sys/hypervisor`. " +
+					"Null if not in a virtual machine, or the hypervisor is not known.",
+				Computed: true,
+			},
+			"evidence": schema.ListAttribute{
+				MarkdownDescription: "What was found, e.g. `/.dockerenv exists`, to explain the result.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *RuntimeEnvironmentDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Nothing to configure
+}
+
+func (d *RuntimeEnvironmentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RuntimeEnvironmentDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	env := &runtimeenv.Environment{}
+
+	if runtime.GOOS != "linux" {
+		resp.Diagnostics.AddWarning("Runtime environment is not available", fmt.Sprintf("Detecting the runtime environment is not supported on %s", runtime.GOOS))
+	} else {
+		env = runtimeenv.Detect(runtimeenv.DefaultRoot, os.Getenv)
+	}
+
+	data.Id = types.StringValue("runtime_environment")
+	data.IsContainer = types.BoolValue(env.IsContainer)
+	data.ContainerEngine = optionalString(env.Container)
+	data.IsKubernetes = types.BoolValue(env.IsKubernetes)
+	data.KubernetesNamespace = optionalString(env.KubernetesNamespace)
+	data.IsWsl = types.BoolValue(env.WSLVersion > 0)
+	data.WslVersion = optionalInt64(int64(env.WSLVersion))
+	data.WslDistro = optionalString(env.WSLDistro)
+	data.IsVirtualMachine = types.BoolValue(env.IsVirtualMachine)
+	data.Hypervisor = optionalString(env.Hypervisor)
+
+	evidence := env.Evidence
+
+	if evidence == nil {
+		evidence = []string{}
+	}
+
+	var diags diag.Diagnostics
+	data.Evidence, diags = types.ListValueFrom(ctx, types.StringType, evidence)
+	resp.Diagnostics.Append(diags...)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Read runtime_environment data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRuntimeEnvironmentDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: `data "localos_runtime_environment" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.localos_runtime_environment.test", "id", "runtime_environment"),
+					resource.TestCheckResourceAttrSet("data.localos_runtime_environment.test", "is_container"),
+					resource.TestCheckResourceAttrSet("data.localos_runtime_environment.test", "is_kubernetes"),
+					resource.TestCheckResourceAttrSet("data.localos_runtime_environment.test", "is_wsl"),
+					resource.TestCheckResourceAttrSet("data.localos_runtime_environment.test", "is_virtual_machine"),
+					resource.TestCheckResourceAttrSet("data.localos_runtime_environment.test", "evidence.#"),
+				),
+			},
+		},
+	})
+}
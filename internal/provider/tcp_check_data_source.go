@@ -0,0 +1,263 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/tcpcheck"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const defaultTcpCheckTimeout = "5s"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource                   = &TcpCheckDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &TcpCheckDataSource{}
+)
+
+func NewTcpCheckDataSource() datasource.DataSource {
+	return &TcpCheckDataSource{}
+}
+
+// TcpCheckDataSource defines the data source implementation.
+type TcpCheckDataSource struct {
+	dialer *net.Dialer
+}
+
+// TcpCheckDataSourceModel describes the data source data model.
+type TcpCheckDataSourceModel struct {
+	Id                    types.String `tfsdk:"id"`
+	Targets               types.List   `tfsdk:"targets"`
+	Timeout               types.String `tfsdk:"timeout"`
+	TLS                   types.Bool   `tfsdk:"tls"`
+	TLSInsecureSkipVerify types.Bool   `tfsdk:"tls_insecure_skip_verify"`
+	RequireAll            types.Bool   `tfsdk:"require_all"`
+	AllReachable          types.Bool   `tfsdk:"all_reachable"`
+	Results               types.List   `tfsdk:"results"` //< TcpCheckResultModel
+}
+
+type TcpCheckResultModel struct {
+	Target          types.String  `tfsdk:"target"`
+	Reachable       types.Bool    `tfsdk:"reachable"`
+	LatencyMs       types.Float64 `tfsdk:"latency_ms"`
+	ResolvedAddress types.String  `tfsdk:"resolved_address"`
+	Error           types.String  `tfsdk:"error"`
+}
+
+func (d *TcpCheckDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tcp_check"
+}
+
+func (d *TcpCheckDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "`tcp_check` data source attempts TCP connections from the machine that reads the data source to each of a list of targets, " +
+			"optionally completing a TLS handshake. Use it with `check` blocks or `require_all` to fail early when e.g. a VPN is not connected.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier",
+				Computed:            true,
+			},
+			"targets": schema.ListAttribute{
+				MarkdownDescription: "List of targets to check, each of the form `host:port`",
+				Required:            true,
+				ElementType:         types.StringType,
+			},
+			"timeout": schema.StringAttribute{
+				MarkdownDescription: "Timeout for each connection attempt as a Go duration string, e.g. `500ms`. Default `" + defaultTcpCheckTimeout + "`.",
+				Optional:            true,
+			},
+			"tls": schema.BoolAttribute{
+				MarkdownDescription: "If `true`, a target is only reachable if a TLS handshake also succeeds. Default `false`.",
+				Optional:            true,
+			},
+			"tls_insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "If `true`, do not verify the certificate presented during the TLS handshake. Default `false`.",
+				Optional:            true,
+			},
+			"require_all": schema.BoolAttribute{
+				MarkdownDescription: "If `true`, raise an error if any target is unreachable. Default `false`.",
+				Optional:            true,
+			},
+			"all_reachable": schema.BoolAttribute{
+				MarkdownDescription: "`true` if every target is reachable",
+				Computed:            true,
+			},
+			"results": schema.ListAttribute{
+				MarkdownDescription: "Result for each target, in the same order as `targets`",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: tcpCheckResultAttributeTypes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *TcpCheckDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	configData, ok := req.ProviderData.(ConfigurationData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected ConfigurationData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.dialer = configData.dialer
+}
+
+func tcpCheckResultAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"target":           types.StringType,
+		"reachable":        types.BoolType,
+		"latency_ms":       types.Float64Type,
+		"resolved_address": types.StringType,
+		"error":            types.StringType,
+	}
+}
+
+func (d *TcpCheckDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data TcpCheckDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Targets.IsUnknown() && !data.Targets.IsNull() && len(data.Targets.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("targets"), "Invalid targets", "At least one target must be given")
+	}
+
+	if !data.Timeout.IsUnknown() && !data.Timeout.IsNull() {
+		if timeout, err := time.ParseDuration(data.Timeout.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("timeout"), "Invalid timeout", err.Error())
+		} else if timeout <= 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("timeout"), "Invalid timeout",
+				fmt.Sprintf("Timeout must be greater than 0, got %q", data.Timeout.ValueString()))
+		}
+	}
+}
+
+func (d *TcpCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TcpCheckDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var targets []string
+	resp.Diagnostics.Append(data.Targets.ElementsAs(ctx, &targets, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeoutString := defaultTcpCheckTimeout
+
+	if !data.Timeout.IsNull() {
+		timeoutString = data.Timeout.ValueString()
+	}
+
+	timeout, err := time.ParseDuration(timeoutString)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid timeout", err.Error())
+		return
+	}
+
+	opts := tcpcheck.Options{
+		Timeout:            timeout,
+		TLS:                data.TLS.ValueBool(),
+		InsecureSkipVerify: data.TLSInsecureSkipVerify.ValueBool(),
+	}
+
+	results := tcpcheck.CheckAll(ctx, d.dialer, targets, opts)
+	resultModels := make([]TcpCheckResultModel, 0, len(results))
+	unreachable := make([]string, 0, len(results))
+
+	for _, r := range results {
+		resultModels = append(resultModels, tcpCheckResultToModel(r))
+
+		if !r.Reachable {
+			unreachable = append(unreachable, fmt.Sprintf("%s: %s", r.Target, r.Error))
+		}
+	}
+
+	resp.Diagnostics.Append(tfsdk.ValueFrom(ctx, resultModels, types.ListType{
+		ElemType: types.ObjectType{
+			AttrTypes: tcpCheckResultAttributeTypes(),
+		},
+	}, &data.Results)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(unreachable) > 0 && data.RequireAll.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Targets unreachable",
+			fmt.Sprintf("The following targets could not be reached from this machine:\n  %s", strings.Join(unreachable, "\n  ")),
+		)
+
+		return
+	}
+
+	data.AllReachable = types.BoolValue(len(unreachable) == 0)
+	data.Id = types.StringValue(strings.Join(targets, ","))
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Read tcp_check data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func tcpCheckResultToModel(r *tcpcheck.Result) TcpCheckResultModel {
+	m := TcpCheckResultModel{
+		Target:          types.StringValue(r.Target),
+		Reachable:       types.BoolValue(r.Reachable),
+		LatencyMs:       types.Float64Null(),
+		ResolvedAddress: types.StringNull(),
+		Error:           types.StringNull(),
+	}
+
+	if r.Reachable {
+		m.LatencyMs = types.Float64Value(float64(r.Latency.Microseconds()) / 1000)
+	}
+
+	if r.ResolvedAddress != "" {
+		m.ResolvedAddress = types.StringValue(r.ResolvedAddress)
+	}
+
+	if r.Error != nil {
+		m.Error = types.StringValue(r.Error.Error())
+	}
+
+	return m
+}
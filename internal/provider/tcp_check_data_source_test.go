@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTcpCheckDataSource(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer l.Close()
+
+	reachable := l.Addr().String()
+	unreachable := closedPort(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: fmt.Sprintf(`
+data "localos_tcp_check" "test" {
+  targets = ["%s", "%s"]
+  timeout = "2s"
+}`, reachable, unreachable),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.localos_tcp_check.test", "all_reachable", "false"),
+					resource.TestCheckResourceAttr("data.localos_tcp_check.test", "results.#", "2"),
+					resource.TestCheckResourceAttr("data.localos_tcp_check.test", "results.0.target", reachable),
+					resource.TestCheckResourceAttr("data.localos_tcp_check.test", "results.0.reachable", "true"),
+					resource.TestCheckResourceAttr("data.localos_tcp_check.test", "results.0.resolved_address", reachable),
+					resource.TestCheckResourceAttrSet("data.localos_tcp_check.test", "results.0.latency_ms"),
+					resource.TestCheckNoResourceAttr("data.localos_tcp_check.test", "results.0.error"),
+					resource.TestCheckResourceAttr("data.localos_tcp_check.test", "results.1.target", unreachable),
+					resource.TestCheckResourceAttr("data.localos_tcp_check.test", "results.1.reachable", "false"),
+					resource.TestCheckResourceAttrSet("data.localos_tcp_check.test", "results.1.error"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTcpCheckDataSourceRequireAll(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "localos_tcp_check" "test" {
+  targets     = ["%s"]
+  require_all = true
+}`, closedPort(t)),
+				ExpectError: regexp.MustCompile(`Targets unreachable`),
+			},
+		},
+	})
+}
+
+func TestAccTcpCheckDataSourceInvalidConfig(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      `data "localos_tcp_check" "test" { targets = [] }`,
+				ExpectError: regexp.MustCompile(`At least one target must be given`),
+			},
+			{
+				Config: `
+data "localos_tcp_check" "test" {
+  targets = ["127.0.0.1:22"]
+  timeout = "0s"
+}`,
+				ExpectError: regexp.MustCompile(`Timeout must be greater than 0`),
+			},
+		},
+	})
+}
+
+// closedPort returns a local address on which nothing is listening.
+func closedPort(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := l.Addr().String()
+	l.Close()
+
+	return addr
+}
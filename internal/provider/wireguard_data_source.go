@@ -0,0 +1,251 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/fireflycons/terraform-provider-localos/internal/helpers/wireguard"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &WireguardDataSource{}
+
+func NewWireguardDataSource() datasource.DataSource {
+	return &WireguardDataSource{}
+}
+
+// WireguardDataSource defines the data source implementation.
+type WireguardDataSource struct {
+}
+
+// WireguardDataSourceModel describes the data source data model.
+type WireguardDataSourceModel struct {
+	Id         types.String `tfsdk:"id"`
+	ConfigDir  types.String `tfsdk:"config_dir"`
+	Interface  types.String `tfsdk:"interface"`
+	Interfaces types.List   `tfsdk:"interfaces"` //< WireguardInterfaceModel
+}
+
+type WireguardInterfaceModel struct {
+	Name       types.String `tfsdk:"name"`
+	ConfigPath types.String `tfsdk:"config_path"`
+	PublicKey  types.String `tfsdk:"public_key"`
+	Addresses  types.List   `tfsdk:"addresses"`
+	DNS        types.List   `tfsdk:"dns"`
+	ListenPort types.Int64  `tfsdk:"listen_port"`
+	MTU        types.Int64  `tfsdk:"mtu"`
+	Up         types.Bool   `tfsdk:"up"`
+	Peers      types.List   `tfsdk:"peers"` //< WireguardPeerModel
+}
+
+type WireguardPeerModel struct {
+	PublicKey           types.String `tfsdk:"public_key"`
+	Endpoint            types.String `tfsdk:"endpoint"`
+	AllowedIPs          types.List   `tfsdk:"allowed_ips"`
+	PersistentKeepalive types.Int64  `tfsdk:"persistent_keepalive"`
+	LatestHandshake     types.String `tfsdk:"latest_handshake"`
+	RxBytes             types.Int64  `tfsdk:"rx_bytes"`
+	TxBytes             types.Int64  `tfsdk:"tx_bytes"`
+}
+
+func (d *WireguardDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_wireguard"
+}
+
+func (d *WireguardDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "`wireguard` data source gets the WireGuard interfaces configured on the machine that reads the data source, " +
+			"from wg-quick configuration files and, where it can be read, the live state of running interfaces. " +
+			"Public keys are derived from the configured private keys. Private and preshared keys are never returned.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource identifier",
+				Computed:            true,
+			},
+			"config_dir": schema.StringAttribute{
+				MarkdownDescription: "Directory containing `<interface>.conf` files. Default `" + wireguard.DefaultConfigDir + "`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"interface": schema.StringAttribute{
+				MarkdownDescription: "If set, only return this interface, e.g. `wg0`.",
+				Optional:            true,
+			},
+			"interfaces": schema.ListAttribute{
+				MarkdownDescription: "Interfaces, sorted by name",
+				Computed:            true,
+				ElementType: types.ObjectType{
+					AttrTypes: wireguardInterfaceAttributeTypes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *WireguardDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Nothing to configure
+}
+
+func wireguardInterfaceAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"name":        types.StringType,
+		"config_path": types.StringType,
+		"public_key":  types.StringType,
+		"addresses": types.ListType{
+			ElemType: types.StringType,
+		},
+		"dns": types.ListType{
+			ElemType: types.StringType,
+		},
+		"listen_port": types.Int64Type,
+		"mtu":         types.Int64Type,
+		"up":          types.BoolType,
+		"peers": types.ListType{
+			ElemType: types.ObjectType{
+				AttrTypes: wireguardPeerAttributeTypes(),
+			},
+		},
+	}
+}
+
+func wireguardPeerAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"public_key": types.StringType,
+		"endpoint":   types.StringType,
+		"allowed_ips": types.ListType{
+			ElemType: types.StringType,
+		},
+		"persistent_keepalive": types.Int64Type,
+		"latest_handshake":     types.StringType,
+		"rx_bytes":             types.Int64Type,
+		"tx_bytes":             types.Int64Type,
+	}
+}
+
+func (d *WireguardDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data WireguardDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ConfigDir.IsNull() {
+		data.ConfigDir = types.StringValue(wireguard.DefaultConfigDir)
+	}
+
+	interfaceModels := make([]WireguardInterfaceModel, 0, 2)
+
+	if runtime.GOOS == "windows" {
+		resp.Diagnostics.AddWarning("WireGuard configuration is not available", fmt.Sprintf("Reading WireGuard configuration is not supported on %s", runtime.GOOS))
+	} else {
+		configured, errs := wireguard.ReadConfigDir(data.ConfigDir.ValueString())
+
+		for _, err := range errs {
+			resp.Diagnostics.AddWarning("Unable to read WireGuard configuration", err.Error())
+		}
+
+		interfaces := wireguard.Merge(configured, wireguard.Live(ctx, wireguard.DefaultSocketDir))
+
+		for _, ifc := range interfaces {
+			if !data.Interface.IsNull() && ifc.Name != data.Interface.ValueString() {
+				continue
+			}
+
+			m, diags := wireguardInterfaceToModel(ctx, ifc)
+			resp.Diagnostics.Append(diags...)
+			interfaceModels = append(interfaceModels, m)
+		}
+	}
+
+	resp.Diagnostics.Append(tfsdk.ValueFrom(ctx, interfaceModels, types.ListType{
+		ElemType: types.ObjectType{
+			AttrTypes: wireguardInterfaceAttributeTypes(),
+		},
+	}, &data.Interfaces)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = data.ConfigDir
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "Read wireguard data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func wireguardInterfaceToModel(ctx context.Context, ifc *wireguard.Interface) (WireguardInterfaceModel, diag.Diagnostics) {
+	var diags, d diag.Diagnostics
+
+	m := WireguardInterfaceModel{
+		Name:       types.StringValue(ifc.Name),
+		ConfigPath: optionalString(ifc.ConfigPath),
+		PublicKey:  optionalString(ifc.PublicKey),
+		ListenPort: optionalInt64(int64(ifc.ListenPort)),
+		MTU:        optionalInt64(int64(ifc.MTU)),
+		Up:         types.BoolValue(ifc.Up),
+	}
+
+	m.Addresses, d = types.ListValueFrom(ctx, types.StringType, ifc.Addresses)
+	diags.Append(d...)
+	m.DNS, d = types.ListValueFrom(ctx, types.StringType, ifc.DNS)
+	diags.Append(d...)
+
+	peers := make([]WireguardPeerModel, 0, len(ifc.Peers))
+
+	for _, p := range ifc.Peers {
+		pm := WireguardPeerModel{
+			PublicKey:           types.StringValue(p.PublicKey),
+			Endpoint:            optionalString(p.Endpoint),
+			PersistentKeepalive: optionalInt64(int64(p.PersistentKeepalive)),
+			LatestHandshake:     optionalTime(p.LatestHandshake),
+			RxBytes:             types.Int64Null(),
+			TxBytes:             types.Int64Null(),
+		}
+
+		// Transfer counts are only known when the interface is up
+		if ifc.Up {
+			pm.RxBytes = types.Int64Value(p.RxBytes)
+			pm.TxBytes = types.Int64Value(p.TxBytes)
+		}
+
+		pm.AllowedIPs, d = types.ListValueFrom(ctx, types.StringType, p.AllowedIPs)
+		diags.Append(d...)
+		peers = append(peers, pm)
+	}
+
+	diags.Append(tfsdk.ValueFrom(ctx, peers, types.ListType{
+		ElemType: types.ObjectType{
+			AttrTypes: wireguardPeerAttributeTypes(),
+		},
+	}, &m.Peers)...)
+
+	return m, diags
+}
+
+// optionalInt64 returns null for zero.
+func optionalInt64(i int64) types.Int64 {
+	if i == 0 {
+		return types.Int64Null()
+	}
+
+	return types.Int64Value(i)
+}
@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// Private key from RFC 7748 section 6.1, and the corresponding public key
+const (
+	testWireguardPrivateKey = "dwdtCnMYpX08FsFyUbJmRd9ML4frwJkqsXf7pR25LCo="
+	testWireguardPublicKey  = "hSDwCYkwp1R0i33ctD73Wg2/Og0mOBr066SpjqqbTmo="
+)
+
+func TestAccWireguardDataSource(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skipf("WireGuard configuration is not supported on %s", runtime.GOOS)
+	}
+
+	dir := t.TempDir()
+	conf := fmt.Sprintf(`[Interface]
+PrivateKey = %s
+Address = 10.100.0.2/32
+
+[Peer]
+PublicKey = 3p7bfXt9wbTTW2HC7OQ1Nz+DQ8hbeGdNrfx+FG+IK08=
+AllowedIPs = 10.100.0.0/24, 10.200.0.0/16
+Endpoint = vpn.example.com:51820
+`, testWireguardPrivateKey)
+
+	if err := os.WriteFile(filepath.Join(dir, "wgtest0.conf"), []byte(conf), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: fmt.Sprintf(`
+data "localos_wireguard" "test" {
+  config_dir = "%s"
+  interface  = "wgtest0"
+}`, filepath.ToSlash(dir)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.localos_wireguard.test", "interfaces.#", "1"),
+					resource.TestCheckResourceAttr("data.localos_wireguard.test", "interfaces.0.public_key", testWireguardPublicKey),
+					resource.TestCheckResourceAttr("data.localos_wireguard.test", "interfaces.0.addresses.0", "10.100.0.2/32"),
+					resource.TestCheckResourceAttr("data.localos_wireguard.test", "interfaces.0.up", "false"),
+					resource.TestCheckResourceAttr("data.localos_wireguard.test", "interfaces.0.peers.0.allowed_ips.#", "2"),
+					resource.TestCheckResourceAttr("data.localos_wireguard.test", "interfaces.0.peers.0.endpoint", "vpn.example.com:51820"),
+					resource.TestCheckNoResourceAttr("data.localos_wireguard.test", "interfaces.0.peers.0.rx_bytes"),
+				),
+			},
+		},
+	})
+}